@@ -0,0 +1,645 @@
+//
+//Copyright 2017 The Kubernetes Authors.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: broadcast.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Urgency filters Messages by spec.urgent.
+type Urgency int32
+
+const (
+	Urgency_URGENCY_ANY        Urgency = 0
+	Urgency_URGENCY_URGENT     Urgency = 1
+	Urgency_URGENCY_NOT_URGENT Urgency = 2
+)
+
+// Enum value maps for Urgency.
+var (
+	Urgency_name = map[int32]string{
+		0: "URGENCY_ANY",
+		1: "URGENCY_URGENT",
+		2: "URGENCY_NOT_URGENT",
+	}
+	Urgency_value = map[string]int32{
+		"URGENCY_ANY":        0,
+		"URGENCY_URGENT":     1,
+		"URGENCY_NOT_URGENT": 2,
+	}
+)
+
+func (x Urgency) Enum() *Urgency {
+	p := new(Urgency)
+	*p = x
+	return p
+}
+
+func (x Urgency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Urgency) Descriptor() protoreflect.EnumDescriptor {
+	return file_broadcast_proto_enumTypes[0].Descriptor()
+}
+
+func (Urgency) Type() protoreflect.EnumType {
+	return &file_broadcast_proto_enumTypes[0]
+}
+
+func (x Urgency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Urgency.Descriptor instead.
+func (Urgency) EnumDescriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{0}
+}
+
+type MessageEvent_Type int32
+
+const (
+	MessageEvent_TYPE_UNSPECIFIED MessageEvent_Type = 0
+	MessageEvent_TYPE_ADDED       MessageEvent_Type = 1
+	MessageEvent_TYPE_MODIFIED    MessageEvent_Type = 2
+	MessageEvent_TYPE_DELETED     MessageEvent_Type = 3
+)
+
+// Enum value maps for MessageEvent_Type.
+var (
+	MessageEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_ADDED",
+		2: "TYPE_MODIFIED",
+		3: "TYPE_DELETED",
+	}
+	MessageEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_ADDED":       1,
+		"TYPE_MODIFIED":    2,
+		"TYPE_DELETED":     3,
+	}
+)
+
+func (x MessageEvent_Type) Enum() *MessageEvent_Type {
+	p := new(MessageEvent_Type)
+	*p = x
+	return p
+}
+
+func (x MessageEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_broadcast_proto_enumTypes[1].Descriptor()
+}
+
+func (MessageEvent_Type) Type() protoreflect.EnumType {
+	return &file_broadcast_proto_enumTypes[1]
+}
+
+func (x MessageEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageEvent_Type.Descriptor instead.
+func (MessageEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Subscriber names the subscriber. It must be a DNS subdomain.
+	Subscriber string `protobuf:"bytes,1,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
+	// Namespace limits the stream to a namespace. Empty is all namespaces.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Label selector limits the stream to the Messages it selects, e.g.
+	// "team=ops,tier!=dev".
+	LabelSelector string  `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	Urgency       Urgency `protobuf:"varint,4,opt,name=urgency,proto3,enum=broadcast.v1.Urgency" json:"urgency,omitempty"`
+	// Resume token is the resume_token of the last event received. The stream
+	// then starts with the Messages changed since, as TYPE_MODIFIED events.
+	// Messages deleted in the meantime are not sent.
+	ResumeToken   string `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_broadcast_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_broadcast_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchRequest) GetSubscriber() string {
+	if x != nil {
+		return x.Subscriber
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetUrgency() Urgency {
+	if x != nil {
+		return x.Urgency
+	}
+	return Urgency_URGENCY_ANY
+}
+
+func (x *WatchRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type MessageEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  MessageEvent_Type      `protobuf:"varint,1,opt,name=type,proto3,enum=broadcast.v1.MessageEvent_Type" json:"type,omitempty"`
+	// Resume token resumes the stream after this event. It is the
+	// resourceVersion of the Message.
+	ResumeToken   string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Message       *Message `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageEvent) Reset() {
+	*x = MessageEvent{}
+	mi := &file_broadcast_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEvent) ProtoMessage() {}
+
+func (x *MessageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_broadcast_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEvent.ProtoReflect.Descriptor instead.
+func (*MessageEvent) Descriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MessageEvent) GetType() MessageEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return MessageEvent_TYPE_UNSPECIFIED
+}
+
+func (x *MessageEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *MessageEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type Message struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Uid             string                 `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,4,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	Generation      int64                  `protobuf:"varint,5,opt,name=generation,proto3" json:"generation,omitempty"`
+	CreationTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Urgent          bool                   `protobuf:"varint,8,opt,name=urgent,proto3" json:"urgent,omitempty"`
+	// Text is the Context of the Message, or its rendered template.
+	Text string `protobuf:"bytes,9,opt,name=text,proto3" json:"text,omitempty"`
+	// State is status.state of the Message.
+	State string `protobuf:"bytes,10,opt,name=state,proto3" json:"state,omitempty"`
+	// Delivery ID is the same for every event of the Message sent to the
+	// subscriber, so that it can drop the ones it already handled.
+	DeliveryId    string `protobuf:"bytes,11,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_broadcast_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_broadcast_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Message) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Message) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Message) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Message) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *Message) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *Message) GetCreationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreationTime
+	}
+	return nil
+}
+
+func (x *Message) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Message) GetUrgent() bool {
+	if x != nil {
+		return x.Urgent
+	}
+	return false
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Message) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type AckRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Subscriber string                 `protobuf:"bytes,1,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
+	Namespace  string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// UID, when set, must be the UID of the Message, so that a Message
+	// recreated with the same name isn't acknowledged by mistake.
+	Uid           string `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckRequest) Reset() {
+	*x = AckRequest{}
+	mi := &file_broadcast_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckRequest) ProtoMessage() {}
+
+func (x *AckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_broadcast_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckRequest.ProtoReflect.Descriptor instead.
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AckRequest) GetSubscriber() string {
+	if x != nil {
+		return x.Subscriber
+	}
+	return ""
+}
+
+func (x *AckRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AckRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AckRequest) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+type AckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckResponse) Reset() {
+	*x = AckResponse{}
+	mi := &file_broadcast_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckResponse) ProtoMessage() {}
+
+func (x *AckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_broadcast_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return file_broadcast_proto_rawDescGZIP(), []int{4}
+}
+
+var File_broadcast_proto protoreflect.FileDescriptor
+
+const file_broadcast_proto_rawDesc = "" +
+	"\n" +
+	"\x0fbroadcast.proto\x12\fbroadcast.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x01\n" +
+	"\fWatchRequest\x12\x1e\n" +
+	"\n" +
+	"subscriber\x18\x01 \x01(\tR\n" +
+	"subscriber\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12%\n" +
+	"\x0elabel_selector\x18\x03 \x01(\tR\rlabelSelector\x12/\n" +
+	"\aurgency\x18\x04 \x01(\x0e2\x15.broadcast.v1.UrgencyR\aurgency\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\"\xea\x01\n" +
+	"\fMessageEvent\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.broadcast.v1.MessageEvent.TypeR\x04type\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\x12/\n" +
+	"\amessage\x18\x03 \x01(\v2\x15.broadcast.v1.MessageR\amessage\"Q\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TYPE_ADDED\x10\x01\x12\x11\n" +
+	"\rTYPE_MODIFIED\x10\x02\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x03\"\xb2\x03\n" +
+	"\aMessage\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\tR\x03uid\x12)\n" +
+	"\x10resource_version\x18\x04 \x01(\tR\x0fresourceVersion\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x05 \x01(\x03R\n" +
+	"generation\x12?\n" +
+	"\rcreation_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreationTime\x129\n" +
+	"\x06labels\x18\a \x03(\v2!.broadcast.v1.Message.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06urgent\x18\b \x01(\bR\x06urgent\x12\x12\n" +
+	"\x04text\x18\t \x01(\tR\x04text\x12\x14\n" +
+	"\x05state\x18\n" +
+	" \x01(\tR\x05state\x12\x1f\n" +
+	"\vdelivery_id\x18\v \x01(\tR\n" +
+	"deliveryId\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"p\n" +
+	"\n" +
+	"AckRequest\x12\x1e\n" +
+	"\n" +
+	"subscriber\x18\x01 \x01(\tR\n" +
+	"subscriber\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x04 \x01(\tR\x03uid\"\r\n" +
+	"\vAckResponse*F\n" +
+	"\aUrgency\x12\x0f\n" +
+	"\vURGENCY_ANY\x10\x00\x12\x12\n" +
+	"\x0eURGENCY_URGENT\x10\x01\x12\x16\n" +
+	"\x12URGENCY_NOT_URGENT\x10\x022\x8c\x01\n" +
+	"\vBroadcaster\x12A\n" +
+	"\x05Watch\x12\x1a.broadcast.v1.WatchRequest\x1a\x1a.broadcast.v1.MessageEvent0\x01\x12:\n" +
+	"\x03Ack\x12\x18.broadcast.v1.AckRequest\x1a\x19.broadcast.v1.AckResponseB4Z2github.com/yasker/example-crd/apis/broadcast/v1;v1b\x06proto3"
+
+var (
+	file_broadcast_proto_rawDescOnce sync.Once
+	file_broadcast_proto_rawDescData []byte
+)
+
+func file_broadcast_proto_rawDescGZIP() []byte {
+	file_broadcast_proto_rawDescOnce.Do(func() {
+		file_broadcast_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_broadcast_proto_rawDesc), len(file_broadcast_proto_rawDesc)))
+	})
+	return file_broadcast_proto_rawDescData
+}
+
+var file_broadcast_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_broadcast_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_broadcast_proto_goTypes = []any{
+	(Urgency)(0),                  // 0: broadcast.v1.Urgency
+	(MessageEvent_Type)(0),        // 1: broadcast.v1.MessageEvent.Type
+	(*WatchRequest)(nil),          // 2: broadcast.v1.WatchRequest
+	(*MessageEvent)(nil),          // 3: broadcast.v1.MessageEvent
+	(*Message)(nil),               // 4: broadcast.v1.Message
+	(*AckRequest)(nil),            // 5: broadcast.v1.AckRequest
+	(*AckResponse)(nil),           // 6: broadcast.v1.AckResponse
+	nil,                           // 7: broadcast.v1.Message.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_broadcast_proto_depIdxs = []int32{
+	0, // 0: broadcast.v1.WatchRequest.urgency:type_name -> broadcast.v1.Urgency
+	1, // 1: broadcast.v1.MessageEvent.type:type_name -> broadcast.v1.MessageEvent.Type
+	4, // 2: broadcast.v1.MessageEvent.message:type_name -> broadcast.v1.Message
+	8, // 3: broadcast.v1.Message.creation_time:type_name -> google.protobuf.Timestamp
+	7, // 4: broadcast.v1.Message.labels:type_name -> broadcast.v1.Message.LabelsEntry
+	2, // 5: broadcast.v1.Broadcaster.Watch:input_type -> broadcast.v1.WatchRequest
+	5, // 6: broadcast.v1.Broadcaster.Ack:input_type -> broadcast.v1.AckRequest
+	3, // 7: broadcast.v1.Broadcaster.Watch:output_type -> broadcast.v1.MessageEvent
+	6, // 8: broadcast.v1.Broadcaster.Ack:output_type -> broadcast.v1.AckResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_broadcast_proto_init() }
+func file_broadcast_proto_init() {
+	if File_broadcast_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_broadcast_proto_rawDesc), len(file_broadcast_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_broadcast_proto_goTypes,
+		DependencyIndexes: file_broadcast_proto_depIdxs,
+		EnumInfos:         file_broadcast_proto_enumTypes,
+		MessageInfos:      file_broadcast_proto_msgTypes,
+	}.Build()
+	File_broadcast_proto = out.File
+	file_broadcast_proto_goTypes = nil
+	file_broadcast_proto_depIdxs = nil
+}
@@ -0,0 +1,194 @@
+//
+//Copyright 2017 The Kubernetes Authors.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: broadcast.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Broadcaster_Watch_FullMethodName = "/broadcast.v1.Broadcaster/Watch"
+	Broadcaster_Ack_FullMethodName   = "/broadcast.v1.Broadcaster/Ack"
+)
+
+// BroadcasterClient is the client API for Broadcaster service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Broadcaster streams Messages to the subscribers that would rather pull
+// them than have the controller push them to a sink.
+type BroadcasterClient interface {
+	// Watch streams the events of the Messages matching the request, starting
+	// with the Messages that already exist, or with the ones changed since
+	// resume_token.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageEvent], error)
+	// Ack records that a subscriber received a Message. It shows in the
+	// deliveries of the Message status as sink "grpc/<subscriber>".
+	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
+}
+
+type broadcasterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBroadcasterClient(cc grpc.ClientConnInterface) BroadcasterClient {
+	return &broadcasterClient{cc}
+}
+
+func (c *broadcasterClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Broadcaster_ServiceDesc.Streams[0], Broadcaster_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, MessageEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Broadcaster_WatchClient = grpc.ServerStreamingClient[MessageEvent]
+
+func (c *broadcasterClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, Broadcaster_Ack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BroadcasterServer is the server API for Broadcaster service.
+// All implementations must embed UnimplementedBroadcasterServer
+// for forward compatibility.
+//
+// Broadcaster streams Messages to the subscribers that would rather pull
+// them than have the controller push them to a sink.
+type BroadcasterServer interface {
+	// Watch streams the events of the Messages matching the request, starting
+	// with the Messages that already exist, or with the ones changed since
+	// resume_token.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[MessageEvent]) error
+	// Ack records that a subscriber received a Message. It shows in the
+	// deliveries of the Message status as sink "grpc/<subscriber>".
+	Ack(context.Context, *AckRequest) (*AckResponse, error)
+	mustEmbedUnimplementedBroadcasterServer()
+}
+
+// UnimplementedBroadcasterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBroadcasterServer struct{}
+
+func (UnimplementedBroadcasterServer) Watch(*WatchRequest, grpc.ServerStreamingServer[MessageEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedBroadcasterServer) Ack(context.Context, *AckRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ack not implemented")
+}
+func (UnimplementedBroadcasterServer) mustEmbedUnimplementedBroadcasterServer() {}
+func (UnimplementedBroadcasterServer) testEmbeddedByValue()                     {}
+
+// UnsafeBroadcasterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BroadcasterServer will
+// result in compilation errors.
+type UnsafeBroadcasterServer interface {
+	mustEmbedUnimplementedBroadcasterServer()
+}
+
+func RegisterBroadcasterServer(s grpc.ServiceRegistrar, srv BroadcasterServer) {
+	// If the following call pancis, it indicates UnimplementedBroadcasterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Broadcaster_ServiceDesc, srv)
+}
+
+func _Broadcaster_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BroadcasterServer).Watch(m, &grpc.GenericServerStream[WatchRequest, MessageEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Broadcaster_WatchServer = grpc.ServerStreamingServer[MessageEvent]
+
+func _Broadcaster_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcasterServer).Ack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcaster_Ack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcasterServer).Ack(ctx, req.(*AckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Broadcaster_ServiceDesc is the grpc.ServiceDesc for Broadcaster service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Broadcaster_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "broadcast.v1.Broadcaster",
+	HandlerType: (*BroadcasterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ack",
+			Handler:    _Broadcaster_Ack_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Broadcaster_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "broadcast.proto",
+}
@@ -15,4 +15,7 @@ limitations under the License.
 */
 
 // +k8s:deepcopy-gen=package
+// +groupName=example.rancher.io
+// +groupGoName=Message
+// +k8s:openapi-gen=true
 package v1
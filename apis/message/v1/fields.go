@@ -0,0 +1,35 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// MessageSelectableFields returns the fields a field selector can match a
+// Message on. Besides the metadata every resource supports, these are the
+// selectableFields of the Message CustomResourceDefinition.
+func MessageSelectableFields(message *Message) fields.Set {
+	return fields.Set{
+		"metadata.name":      message.ObjectMeta.Name,
+		"metadata.namespace": message.ObjectMeta.Namespace,
+		"spec.urgent":        strconv.FormatBool(message.Spec.Urgent),
+		"status.state":       string(message.Status.State),
+	}
+}
@@ -33,11 +33,28 @@ const GroupName = "example.rancher.io"
 // SchemeGroupVersion is the group version used to register these objects.
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
 
+// Resource takes an unqualified resource and returns a Group qualified GroupResource.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
 // addKnownTypes adds the set of types defined in this package to the supplied scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Message{},
 		&MessageList{},
+		&BroadcastChannel{},
+		&BroadcastChannelList{},
+		&Subscription{},
+		&SubscriptionList{},
+		&MessageTemplate{},
+		&MessageTemplateList{},
+		&MessageAcknowledgement{},
+		&MessageAcknowledgementList{},
+		&EscalationPolicy{},
+		&EscalationPolicyList{},
+		&DeadLetter{},
+		&DeadLetterList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,9 +18,18 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-const MessageResourcePlural = "messages"
+const (
+	MessageResourcePlural   = "messages"
+	MessageResourceSingular = "message"
+	MessageShortName        = "msg"
+)
+
+// MessageCategories are the resource groups `kubectl get` expands to include
+// Messages, e.g. `kubectl get all` or `kubectl get messaging`.
+var MessageCategories = []string{"all", "messaging"}
 
 // +genclient
 
@@ -32,22 +41,346 @@ type Message struct {
 	Status            MessageStatus `json:"status,omitempty"`
 }
 
+// MessagePrinterColumns are the columns `kubectl get messages` shows. Keep
+// the JSON paths in line with the json tags of Message.
+var MessagePrinterColumns = []PrinterColumn{
+	{Name: "Urgent", Type: "boolean", JSONPath: ".spec.urgent", Description: "Whether the message is urgent"},
+	{Name: "State", Type: "string", JSONPath: ".status.state", Description: "Broadcast state of the message"},
+	{Name: "Delivered", Type: "string", JSONPath: `.status.deliveries[?(@.state=="Delivered")].sink`, Description: "Sinks the message was delivered to"},
+	{Name: "Ack", Type: "string", JSONPath: ".status.acknowledgement.state", Description: "Acknowledgement state of the message"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
 type MessageSpec struct {
-	Context string `json:"context"`
-	Urgent  bool   `json:"urgent"`
+	// Context is the text of the message. It is ignored when the message
+	// is rendered from a template.
+	// +optional
+	Context string `json:"context,omitempty"`
+	// TemplateRef renders the text of the message from a MessageTemplate
+	// in its namespace.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+	// +optional
+	Urgent bool `json:"urgent"`
+	// ChannelRef names the BroadcastChannel the message is broadcast
+	// through. Messages without one go to the controller's default sinks.
+	// +optional
+	ChannelRef *ChannelReference `json:"channelRef,omitempty"`
+	// Topic publishes the message to the Subscriptions of this topic in
+	// its namespace instead of broadcasting it through a channel.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// Acknowledgement overrides how the controller waits for an urgent
+	// message to be acknowledged. Non-urgent messages are only tracked
+	// if it is set.
+	// +optional
+	Acknowledgement *AcknowledgementPolicy `json:"acknowledgement,omitempty"`
+	// EscalationPolicyRef names the EscalationPolicy in the message's
+	// namespace that is followed when the message goes unacknowledged or
+	// undelivered. It takes precedence over the escalation channel of the
+	// acknowledgement policy.
+	// +optional
+	EscalationPolicyRef *EscalationPolicyReference `json:"escalationPolicyRef,omitempty"`
+	// DeliverAt delays broadcasting the message until the given time.
+	// +optional
+	DeliverAt *metav1.Time `json:"deliverAt,omitempty"`
+	// IdempotencyKey identifies the message across retries of its
+	// creation. A message with the same key as one created shortly before
+	// it through the same channel is suppressed instead of broadcast.
+	// +optional
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	// OrderingKey delivers the message only after every message with the
+	// same key in its namespace created before it was broadcast or
+	// suppressed. Messages with different keys are delivered in parallel.
+	// +optional
+	OrderingKey string `json:"orderingKey,omitempty"`
+	// Schedule makes the message recurring. A recurring message is not
+	// broadcast itself, it creates a copy of itself without the schedule
+	// at every scheduled time, which is broadcast instead.
+	// +optional
+	Schedule *MessageSchedule `json:"schedule,omitempty"`
+}
+
+// ChannelReference refers to a cluster-scoped BroadcastChannel.
+type ChannelReference struct {
+	Name string `json:"name"`
+}
+
+// AcknowledgementPolicy is how long a broadcasted Message may go without a
+// MessageAcknowledgement before it is escalated.
+type AcknowledgementPolicy struct {
+	// Timeout defaults to the controller's acknowledgement timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// EscalationChannelRef names the BroadcastChannel an unacknowledged
+	// message is escalated to. Without one, the message is broadcast
+	// again the way it was the first time.
+	// +optional
+	EscalationChannelRef *ChannelReference `json:"escalationChannelRef,omitempty"`
+}
+
+// MessageSchedule is when a recurring Message is sent.
+type MessageSchedule struct {
+	// Cron is a standard five field cron expression, e.g. "0 9 * * 1-5".
+	Cron string `json:"cron"`
+	// TimeZone is the IANA name of the time zone Cron is interpreted in.
+	// Defaults to the time zone of the controller.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// HistoryLimit is how many broadcasted Messages created by the
+	// schedule are kept. Defaults to 3.
+	// +optional
+	// +k8s:validation:minimum=0
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// DefaultScheduleHistoryLimit is the history limit of schedules that don't
+// set one.
+const DefaultScheduleHistoryLimit = 3
+
+const (
+	// ScheduledByLabel is set on the Messages a recurring Message creates,
+	// to the name of the recurring Message.
+	ScheduledByLabel = GroupName + "/scheduled-by"
+	// ScheduledAtAnnotation is set on the Messages a recurring Message
+	// creates, to the RFC 3339 time they were scheduled for.
+	ScheduledAtAnnotation = GroupName + "/scheduled-at"
+)
+
+// EscalationPolicyReference refers to an EscalationPolicy in the same
+// namespace.
+type EscalationPolicyReference struct {
+	Name string `json:"name"`
+}
+
+// TemplateReference refers to a MessageTemplate and the values of its
+// parameters.
+type TemplateReference struct {
+	Name string `json:"name"`
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 type MessageStatus struct {
 	State MessageState `json:"state,omitempty"`
+	// Subscribers is the number of Subscriptions matching a message
+	// published to a topic. Their deliveries are recorded as
+	// "subscription/<namespace>/<name>".
+	// +optional
+	Subscribers int32 `json:"subscribers,omitempty"`
+	// Rendered is the text rendered from the template of the message. It
+	// is kept once the message is broadcasted, even if the template
+	// changes afterwards.
+	// +optional
+	Rendered string `json:"rendered,omitempty"`
+	// +listType=map
+	// +listMapKey=sink
+	Deliveries []MessageDelivery `json:"deliveries,omitempty"`
+	// Acknowledgement tracks whether a message that needs to be
+	// acknowledged was.
+	// +optional
+	Acknowledgement *AcknowledgementStatus `json:"acknowledgement,omitempty"`
+	// FailedAttempts are the latest failed delivery attempts of the
+	// message, oldest first.
+	// +optional
+	// +listType=atomic
+	FailedAttempts []DeliveryAttempt `json:"failedAttempts,omitempty"`
+	// DroppedAttempts is how many earlier failed delivery attempts were
+	// dropped from FailedAttempts, which keeps only the latest
+	// MaxFailedAttempts.
+	// +optional
+	DroppedAttempts int32 `json:"droppedAttempts,omitempty"`
+	// DuplicateOf is the message a suppressed message duplicates.
+	// +optional
+	DuplicateOf *MessageReference `json:"duplicateOf,omitempty"`
+	// Escalation is where the message is in its EscalationPolicy.
+	// +optional
+	Escalation *EscalationStatus `json:"escalation,omitempty"`
+	// LastScheduleTime is the last time a recurring message created a
+	// Message.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is the next time a recurring message creates a
+	// Message.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MessageDelivery records the outcome of broadcasting a Message to one sink.
+type MessageDelivery struct {
+	Sink        string        `json:"sink"`
+	State       DeliveryState `json:"state"`
+	Error       string        `json:"error,omitempty"`
+	DeliveredAt *metav1.Time  `json:"deliveredAt,omitempty"`
+	// Attempts is how many times delivering to the sink failed.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+}
+
+// DeliveryAttempt is a failed attempt to deliver a Message to a sink.
+type DeliveryAttempt struct {
+	Sink  string      `json:"sink"`
+	Error string      `json:"error"`
+	Time  metav1.Time `json:"time"`
+}
+
+// MaxFailedAttempts is how many of the latest failed delivery attempts a
+// Message keeps in its status.
+const MaxFailedAttempts = 10
+
+// AcknowledgementStatus records who acknowledged a Message, or until when
+// it may go unacknowledged.
+type AcknowledgementStatus struct {
+	State AcknowledgementState `json:"state"`
+	// Deadline is when the message gets escalated unless it is
+	// acknowledged before.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+	// +optional
+	AcknowledgedBy string `json:"acknowledgedBy,omitempty"`
+	// +optional
+	AcknowledgedAt *metav1.Time `json:"acknowledgedAt,omitempty"`
+	// +optional
+	EscalatedAt *metav1.Time `json:"escalatedAt,omitempty"`
+}
+
+type AcknowledgementState string
+
+const (
+	AcknowledgementStatePending      AcknowledgementState = "Pending"
+	AcknowledgementStateAcknowledged AcknowledgementState = "Acknowledged"
+	AcknowledgementStateEscalated    AcknowledgementState = "Escalated"
+)
+
+// EscalationStatus is the position of a Message in the steps of its
+// EscalationPolicy. It is all the controller needs to resume the
+// escalation after a restart.
+type EscalationStatus struct {
+	Policy string           `json:"policy"`
+	Reason EscalationReason `json:"reason"`
+	State  EscalationState  `json:"state"`
+	// Step is the index of the step that runs next.
+	Step int32 `json:"step"`
+	// Repeats is how many times the step has already run.
+	// +optional
+	Repeats int32 `json:"repeats,omitempty"`
+	// NextAt is when the step runs next.
+	// +optional
+	NextAt *metav1.Time `json:"nextAt,omitempty"`
+	// +optional
+	LastEscalatedAt *metav1.Time `json:"lastEscalatedAt,omitempty"`
 }
 
+type EscalationReason string
+
+const (
+	EscalationReasonUnacknowledged EscalationReason = "Unacknowledged"
+	EscalationReasonUndelivered    EscalationReason = "Undelivered"
+)
+
+type EscalationState string
+
+const (
+	// EscalationStateActive escalations run their next step at NextAt.
+	EscalationStateActive EscalationState = "Active"
+	// EscalationStateResolved escalations were stopped because the
+	// message got acknowledged or delivered.
+	EscalationStateResolved EscalationState = "Resolved"
+	// EscalationStateCompleted escalations ran all of their steps.
+	EscalationStateCompleted EscalationState = "Completed"
+)
+
+type DeliveryState string
+
+const (
+	DeliveryStateDelivered DeliveryState = "Delivered"
+	DeliveryStateFailed    DeliveryState = "Failed"
+	// DeliveryStateParked deliveries are waiting for the circuit breaker
+	// of their sink to close.
+	DeliveryStateParked DeliveryState = "Parked"
+)
+
 type MessageState string
 
 const (
-	MessageStateCreated     MessageState = "Created"
+	MessageStateCreated MessageState = "Created"
+	// MessageStateScheduled messages wait for their deliverAt time, or
+	// are recurring.
+	MessageStateScheduled MessageState = "Scheduled"
+	// MessageStateSuppressed messages are not broadcast because they
+	// duplicate an earlier message with the same idempotency key.
+	MessageStateSuppressed MessageState = "Suppressed"
+	// MessageStateFailed messages ran out of delivery attempts. They are
+	// not retried, a DeadLetter keeps what is needed to replay them.
+	MessageStateFailed      MessageState = "Failed"
 	MessageStateBroadcasted MessageState = "Broadcasted"
 )
 
+const (
+	// MessageConditionChannelResolved tells whether the BroadcastChannel a
+	// Message references exists and admits the Message's namespace.
+	MessageConditionChannelResolved = "ChannelResolved"
+
+	MessageReasonChannelResolved  = "ChannelResolved"
+	MessageReasonChannelNotFound  = "ChannelNotFound"
+	MessageReasonChannelForbidden = "ChannelForbidden"
+	MessageReasonChannelInvalid   = "ChannelInvalid"
+)
+
+const (
+	// MessageConditionTemplateRendered tells whether the text of a Message
+	// referencing a MessageTemplate was rendered.
+	MessageConditionTemplateRendered = "TemplateRendered"
+
+	MessageReasonTemplateRendered = "TemplateRendered"
+	MessageReasonTemplateNotFound = "TemplateNotFound"
+	MessageReasonTemplateInvalid  = "TemplateInvalid"
+	MessageReasonParameterMissing = "ParameterMissing"
+	MessageReasonParameterUnknown = "ParameterUnknown"
+)
+
+const (
+	// MessageConditionBlocked tells whether a Message with an ordering key
+	// waits for an earlier Message with the same key to be broadcast.
+	MessageConditionBlocked = "Blocked"
+
+	MessageReasonWaitingForPredecessor = "WaitingForPredecessor"
+	MessageReasonPredecessorsDone      = "PredecessorsDone"
+)
+
+const (
+	// MessageConditionThrottled tells whether the delivery of a Message is
+	// postponed by a rate limit. The reason names the rate limit.
+	MessageConditionThrottled = "Throttled"
+
+	MessageReasonGlobalRateLimited    = "GlobalRateLimited"
+	MessageReasonNamespaceRateLimited = "NamespaceRateLimited"
+	MessageReasonChannelRateLimited   = "ChannelRateLimited"
+	MessageReasonSinkRateLimited      = "SinkRateLimited"
+	MessageReasonNotThrottled         = "NotThrottled"
+)
+
+const (
+	// MessageConditionScheduleValid tells whether the schedule of a
+	// recurring Message can be parsed.
+	MessageConditionScheduleValid = "ScheduleValid"
+
+	MessageReasonScheduleValid   = "ScheduleValid"
+	MessageReasonScheduleInvalid = "ScheduleInvalid"
+)
+
+const (
+	// MessageConditionEscalationPolicyResolved tells whether the
+	// EscalationPolicy a Message references exists.
+	MessageConditionEscalationPolicyResolved = "EscalationPolicyResolved"
+
+	MessageReasonEscalationPolicyResolved = "EscalationPolicyResolved"
+	MessageReasonEscalationPolicyNotFound = "EscalationPolicyNotFound"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type MessageList struct {
@@ -55,3 +388,421 @@ type MessageList struct {
 	metav1.ListMeta `json:"metadata"`
 	Items           []Message `json:"items"`
 }
+
+const (
+	BroadcastChannelResourcePlural   = "broadcastchannels"
+	BroadcastChannelResourceSingular = "broadcastchannel"
+	BroadcastChannelShortName        = "bch"
+)
+
+// BroadcastChannelCategories are the resource groups `kubectl get` expands
+// to include BroadcastChannels.
+var BroadcastChannelCategories = []string{"messaging"}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BroadcastChannel is a cluster-scoped route Messages can be broadcast
+// through. It decides which sink delivers the Messages, how fast, and which
+// namespaces may use it.
+type BroadcastChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              BroadcastChannelSpec `json:"spec"`
+}
+
+// BroadcastChannelPrinterColumns are the columns `kubectl get
+// broadcastchannels` shows.
+var BroadcastChannelPrinterColumns = []PrinterColumn{
+	{Name: "Sink", Type: "string", JSONPath: ".spec.sink", Description: "Type of sink messages are delivered with"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type BroadcastChannelSpec struct {
+	// Sink is the type of sink delivering the channel's Messages.
+	Sink SinkType `json:"sink"`
+	// Settings configure the sink. Which keys are understood depends on
+	// the sink type.
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
+	// RateLimit bounds how fast Messages are delivered on the channel.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// DedupWindow is how long after a Message another one with the same
+	// idempotency key is suppressed as its duplicate. Defaults to the
+	// controller's dedup window.
+	// +optional
+	DedupWindow *metav1.Duration `json:"dedupWindow,omitempty"`
+	// AllowedNamespaces are the namespaces whose Messages may use the
+	// channel. An empty list allows every namespace.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+type SinkType string
+
+const (
+	SinkTypeLog      SinkType = "Log"
+	SinkTypeWebhook  SinkType = "Webhook"
+	SinkTypeSMTP     SinkType = "SMTP"
+	SinkTypeSyslog   SinkType = "Syslog"
+	SinkTypeJournald SinkType = "Journald"
+	SinkTypeKafka    SinkType = "Kafka"
+	SinkTypeNATS     SinkType = "NATS"
+)
+
+// RateLimit is a token bucket: it refills Limit tokens every Period and
+// holds at most Burst of them.
+type RateLimit struct {
+	Limit int32 `json:"limit"`
+	// Period defaults to one second.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Burst defaults to Limit.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BroadcastChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BroadcastChannel `json:"items"`
+}
+
+const (
+	SubscriptionResourcePlural   = "subscriptions"
+	SubscriptionResourceSingular = "subscription"
+	SubscriptionShortName        = "sub"
+)
+
+// SubscriptionCategories are the resource groups `kubectl get` expands to
+// include Subscriptions.
+var SubscriptionCategories = []string{"messaging"}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Subscription subscribes a recipient to the Messages published to a topic
+// in its namespace. Only Messages published after the Subscription was
+// created are delivered to it.
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              SubscriptionSpec `json:"spec"`
+}
+
+// SubscriptionPrinterColumns are the columns `kubectl get subscriptions`
+// shows.
+var SubscriptionPrinterColumns = []PrinterColumn{
+	{Name: "Topic", Type: "string", JSONPath: ".spec.topic", Description: "Topic the subscription receives messages of"},
+	{Name: "Sink", Type: "string", JSONPath: ".spec.recipient.sink", Description: "Type of sink messages are delivered with"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type SubscriptionSpec struct {
+	Topic     string    `json:"topic"`
+	Recipient Recipient `json:"recipient"`
+	// Filter narrows down the Messages of the topic that are delivered.
+	// +optional
+	Filter *SubscriptionFilter `json:"filter,omitempty"`
+}
+
+// Recipient is the endpoint a Subscription delivers to.
+type Recipient struct {
+	Sink SinkType `json:"sink"`
+	// Settings configure the sink. Which keys are understood depends on
+	// the sink type.
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// SubscriptionFilter matches Messages by their labels and urgency. Unset
+// fields match every Message.
+type SubscriptionFilter struct {
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// +optional
+	Urgent *bool `json:"urgent,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Subscription `json:"items"`
+}
+
+const (
+	MessageTemplateResourcePlural   = "messagetemplates"
+	MessageTemplateResourceSingular = "messagetemplate"
+	MessageTemplateShortName        = "msgt"
+)
+
+// MessageTemplateCategories are the resource groups `kubectl get` expands
+// to include MessageTemplates.
+var MessageTemplateCategories = []string{"messaging"}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MessageTemplate is a text/template Messages in its namespace can render
+// their text from, given values for its parameters.
+type MessageTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              MessageTemplateSpec `json:"spec"`
+}
+
+// MessageTemplatePrinterColumns are the columns `kubectl get
+// messagetemplates` shows.
+var MessageTemplatePrinterColumns = []PrinterColumn{
+	{Name: "Parameters", Type: "string", JSONPath: ".spec.parameters[*].name", Description: "Parameters the template takes"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type MessageTemplateSpec struct {
+	// Body is a text/template executed with the parameters as its data,
+	// e.g. "{{ .service }} is down". Only a restricted set of functions
+	// is available to it.
+	Body string `json:"body"`
+	// Parameters are the parameters the template takes. Messages can't
+	// pass parameters that are not listed here.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+}
+
+type TemplateParameter struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Required parameters must be passed by every Message using the
+	// template, unless they have a default.
+	// +optional
+	Required bool `json:"required,omitempty"`
+	// +optional
+	Default string `json:"default,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MessageTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MessageTemplate `json:"items"`
+}
+
+const (
+	MessageAcknowledgementResourcePlural   = "messageacknowledgements"
+	MessageAcknowledgementResourceSingular = "messageacknowledgement"
+	MessageAcknowledgementShortName        = "ack"
+)
+
+// MessageAcknowledgementCategories are the resource groups `kubectl get`
+// expands to include MessageAcknowledgements.
+var MessageAcknowledgementCategories = []string{"messaging"}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MessageAcknowledgement records that someone acknowledged a Message in its
+// namespace. The first acknowledgement of a Message is the one recorded in
+// its status.
+type MessageAcknowledgement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              MessageAcknowledgementSpec `json:"spec"`
+}
+
+// MessageAcknowledgementPrinterColumns are the columns `kubectl get
+// messageacknowledgements` shows.
+var MessageAcknowledgementPrinterColumns = []PrinterColumn{
+	{Name: "Message", Type: "string", JSONPath: ".spec.messageRef.name", Description: "Message that was acknowledged"},
+	{Name: "By", Type: "string", JSONPath: ".spec.acknowledgedBy", Description: "Who acknowledged the message"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type MessageAcknowledgementSpec struct {
+	MessageRef MessageReference `json:"messageRef"`
+	// AcknowledgedBy is who acknowledged the message. The admission policy
+	// the controller installs only admits the name of the user creating the
+	// acknowledgement, and does not let it change.
+	AcknowledgedBy string `json:"acknowledgedBy"`
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// MessageReference refers to a Message in the same namespace.
+type MessageReference struct {
+	Name string `json:"name"`
+	// UID tells the message apart from earlier or later ones of the same
+	// name.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MessageAcknowledgementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MessageAcknowledgement `json:"items"`
+}
+
+const (
+	EscalationPolicyResourcePlural   = "escalationpolicies"
+	EscalationPolicyResourceSingular = "escalationpolicy"
+	EscalationPolicyShortName        = "esc"
+)
+
+// EscalationPolicyCategories are the resource groups `kubectl get` expands
+// to include EscalationPolicies.
+var EscalationPolicyCategories = []string{"messaging"}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EscalationPolicy is the sequence of steps the Messages referencing it go
+// through when they are not acknowledged in time, or not delivered in time.
+type EscalationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              EscalationPolicySpec `json:"spec"`
+}
+
+// EscalationPolicyPrinterColumns are the columns `kubectl get
+// escalationpolicies` shows.
+var EscalationPolicyPrinterColumns = []PrinterColumn{
+	{Name: "Channels", Type: "string", JSONPath: ".spec.steps[*].channelRef.name", Description: "Channels the steps escalate to"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type EscalationPolicySpec struct {
+	// UndeliveredAfter escalates Messages that are still not delivered to
+	// all of their sinks this long after they were created. Without it,
+	// only unacknowledged Messages are escalated.
+	// +optional
+	UndeliveredAfter *metav1.Duration `json:"undeliveredAfter,omitempty"`
+	Steps            []EscalationStep `json:"steps"`
+}
+
+// EscalationStep broadcasts the Message to a channel after a delay, and
+// again after the same delay Repeat more times.
+type EscalationStep struct {
+	// Delay is how long to wait after the escalation started or the
+	// previous step ran.
+	Delay      metav1.Duration  `json:"delay"`
+	ChannelRef ChannelReference `json:"channelRef"`
+	// +optional
+	Repeat int32 `json:"repeat,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type EscalationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []EscalationPolicy `json:"items"`
+}
+
+const (
+	DeadLetterResourcePlural   = "deadletters"
+	DeadLetterResourceSingular = "deadletter"
+	DeadLetterShortName        = "dl"
+)
+
+// DeadLetterCategories are the resource groups `kubectl get` expands to
+// include DeadLetters.
+var DeadLetterCategories = []string{"messaging"}
+
+// ReplayOfAnnotation is set on the Messages replayed from a DeadLetter, to
+// the "<namespace>/<name>" of the DeadLetter.
+const ReplayOfAnnotation = GroupName + "/replay-of"
+
+const (
+	// AlertFingerprintLabel is set on the Messages created from
+	// Alertmanager alerts, to the fingerprint of the alert.
+	AlertFingerprintLabel = GroupName + "/alert-fingerprint"
+	// AlertStatusLabel is set on the Messages created from Alertmanager
+	// alerts, to AlertStatusFiring or AlertStatusResolved.
+	AlertStatusLabel = GroupName + "/alert-status"
+	// AlertResolvedAtAnnotation is set on the Messages of resolved
+	// alerts, to the RFC 3339 time the alert ended.
+	AlertResolvedAtAnnotation = GroupName + "/alert-resolved-at"
+)
+
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeadLetter is a copy of a Message that failed to be delivered, kept so
+// that it can be inspected and replayed.
+type DeadLetter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              DeadLetterSpec `json:"spec"`
+}
+
+// DeadLetterPrinterColumns are the columns `kubectl get deadletters` shows.
+var DeadLetterPrinterColumns = []PrinterColumn{
+	{Name: "Namespace", Type: "string", JSONPath: ".spec.message.namespace", Description: "Namespace of the failed message"},
+	{Name: "Message", Type: "string", JSONPath: ".spec.message.name", Description: "Name of the failed message"},
+	{Name: "Failed", Type: "date", JSONPath: ".spec.failedAt", Description: "When the message was given up on"},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+type DeadLetterSpec struct {
+	Message DeadLetterMessage `json:"message"`
+	// Attempts are the latest failed delivery attempts of the message.
+	// +listType=atomic
+	Attempts []DeliveryAttempt `json:"attempts"`
+	// DroppedAttempts is how many earlier failed delivery attempts of the
+	// message are not in Attempts.
+	// +optional
+	DroppedAttempts int32       `json:"droppedAttempts,omitempty"`
+	FailedAt        metav1.Time `json:"failedAt"`
+}
+
+// DeadLetterMessage is the failed Message as it was created.
+type DeadLetterMessage struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+	// +optional
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Spec              MessageSpec       `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DeadLetterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []DeadLetter `json:"items"`
+}
+
+// PrinterColumn is an additional column `kubectl get` prints for a resource.
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type PrinterColumn struct {
+	Name        string
+	Type        string
+	JSONPath    string
+	Description string
+}
@@ -17,46 +17,20 @@ limitations under the License.
 package client
 
 import (
+	"context"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/serializer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/rest"
 
 	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
 )
 
-func NewClient(cfg *rest.Config) (*rest.RESTClient, *runtime.Scheme, error) {
-	scheme := runtime.NewScheme()
-	if err := messagev1.AddToScheme(scheme); err != nil {
-		return nil, nil, err
-	}
-
-	config := *cfg
-	config.GroupVersion = &messagev1.SchemeGroupVersion
-	config.APIPath = "/apis"
-	config.ContentType = runtime.ContentTypeJSON
-	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
-
-	client, err := rest.RESTClientFor(&config)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return client, scheme, nil
-}
-
-func WaitForMessageInstanceProcessed(client *rest.RESTClient, name string) error {
-	return wait.Poll(100*time.Millisecond, 10*time.Second, func() (bool, error) {
-		var message messagev1.Message
-		err := client.Get().
-			Resource(messagev1.MessageResourcePlural).
-			Namespace(apiv1.NamespaceDefault).
-			Name(name).
-			Do().Into(&message)
-
+func WaitForMessageInstanceProcessed(ctx context.Context, clientset messageClientset.Interface, name string) error {
+	return wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 10*time.Second, false, func(ctx context.Context) (bool, error) {
+		message, err := clientset.MessageV1().Messages(apiv1.NamespaceDefault).Get(ctx, name, metav1.GetOptions{})
 		if err == nil && message.Status.State == messagev1.MessageStateBroadcasted {
 			return true, nil
 		}
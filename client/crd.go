@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// FieldManager is the field manager the CRDs are applied with.
+const FieldManager = "message-controller"
+
+// applyCustomResourceDefinition creates crd, or updates it to crd if it
+// exists, with a server-side apply, and waits for it to be established. A
+// CRD that did not exist before and does not get established is deleted
+// again.
+func applyCustomResourceDefinition(ctx context.Context, clientset apiextensionsclient.Interface, crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crds := clientset.ApiextensionsV1().CustomResourceDefinitions()
+	name := crd.ObjectMeta.Name
+	_, err := crds.Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	created := apierrors.IsNotFound(err)
+
+	crd.TypeMeta = metav1.TypeMeta{
+		APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+		Kind:       "CustomResourceDefinition",
+	}
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	// The CRDs are owned by the controller, so it takes over the fields
+	// changed by hand.
+	force := true
+	_, err = crds.Patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: FieldManager, Force: &force})
+	if err != nil {
+		return nil, err
+	}
+
+	// wait for CRD being established
+	err = wait.PollUntilContextTimeout(ctx, 500*time.Millisecond, 60*time.Second, false, func(ctx context.Context) (bool, error) {
+		crd, err = crds.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, cond := range crd.Status.Conditions {
+			switch cond.Type {
+			case apiextensionsv1.Established:
+				if cond.Status == apiextensionsv1.ConditionTrue {
+					return true, err
+				}
+			case apiextensionsv1.NamesAccepted:
+				if cond.Status == apiextensionsv1.ConditionFalse {
+					fmt.Printf("Name conflict: %v\n", cond.Reason)
+				}
+			}
+		}
+		return false, err
+	})
+	if err != nil && created {
+		deleteErr := crds.Delete(ctx, name, metav1.DeleteOptions{})
+		if deleteErr != nil {
+			return nil, errors.NewAggregate([]error{err, deleteErr})
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func printerColumns(columns []messagev1.PrinterColumn) []apiextensionsv1.CustomResourceColumnDefinition {
+	definitions := make([]apiextensionsv1.CustomResourceColumnDefinition, 0, len(columns))
+	for _, column := range columns {
+		definitions = append(definitions, apiextensionsv1.CustomResourceColumnDefinition{
+			Name:        column.Name,
+			Type:        column.Type,
+			JSONPath:    column.JSONPath,
+			Description: column.Description,
+		})
+	}
+	return definitions
+}
@@ -17,15 +17,12 @@ limitations under the License.
 package client
 
 import (
-	"fmt"
+	"context"
 	"reflect"
-	"time"
 
-	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	// Uncomment the following line to load the gcp plugin (only required to authenticate against GKE clusters).
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
@@ -35,52 +32,50 @@ import (
 // CRD Name must be plural + groupname
 const messageCRDName = messagev1.MessageResourcePlural + "." + messagev1.GroupName
 
-func CreateCustomResourceDefinition(clientset apiextensionsclient.Interface) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
-	crd := &apiextensionsv1beta1.CustomResourceDefinition{
+// CreateCustomResourceDefinition creates the CustomResourceDefinition of
+// Messages, or updates it if it exists, and waits for it to be established.
+func CreateCustomResourceDefinition(ctx context.Context, clientset apiextensionsclient.Interface) (*apiextensionsv1.CustomResourceDefinition, error) {
+	schema, err := openAPIV3Schema(reflect.TypeOf(messagev1.Message{}).PkgPath() + ".Message")
+	if err != nil {
+		return nil, err
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: messageCRDName,
 		},
-		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
-			Group:   messagev1.GroupName,
-			Version: messagev1.SchemeGroupVersion.Version,
-			Scope:   apiextensionsv1beta1.NamespaceScoped,
-			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
-				Plural: messagev1.MessageResourcePlural,
-				Kind:   reflect.TypeOf(messagev1.Message{}).Name(),
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: messagev1.GroupName,
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     messagev1.MessageResourcePlural,
+				Singular:   messagev1.MessageResourceSingular,
+				ShortNames: []string{messagev1.MessageShortName},
+				Categories: messagev1.MessageCategories,
+				Kind:       reflect.TypeOf(messagev1.Message{}).Name(),
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    messagev1.SchemeGroupVersion.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: schema,
+					},
+					// The status subresource lets the controller apply status
+					// without conflicting with whoever owns the spec.
+					Subresources: &apiextensionsv1.CustomResourceSubresources{
+						Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+					},
+					// Keep in sync with messagev1.MessageSelectableFields.
+					SelectableFields: []apiextensionsv1.SelectableField{
+						{JSONPath: ".spec.urgent"},
+						{JSONPath: ".status.state"},
+					},
+					AdditionalPrinterColumns: printerColumns(messagev1.MessagePrinterColumns),
+				},
 			},
 		},
 	}
-	_, err := clientset.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
-	if err != nil {
-		return nil, err
-	}
-
-	// wait for CRD being established
-	err = wait.Poll(500*time.Millisecond, 60*time.Second, func() (bool, error) {
-		crd, err = clientset.ApiextensionsV1beta1().CustomResourceDefinitions().Get(messageCRDName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
-		}
-		for _, cond := range crd.Status.Conditions {
-			switch cond.Type {
-			case apiextensionsv1beta1.Established:
-				if cond.Status == apiextensionsv1beta1.ConditionTrue {
-					return true, err
-				}
-			case apiextensionsv1beta1.NamesAccepted:
-				if cond.Status == apiextensionsv1beta1.ConditionFalse {
-					fmt.Printf("Name conflict: %v\n", cond.Reason)
-				}
-			}
-		}
-		return false, err
-	})
-	if err != nil {
-		deleteErr := clientset.ApiextensionsV1beta1().CustomResourceDefinitions().Delete(messageCRDName, nil)
-		if deleteErr != nil {
-			return nil, errors.NewAggregate([]error{err, deleteErr})
-		}
-		return nil, err
-	}
-	return crd, nil
+	return applyCustomResourceDefinition(ctx, clientset, crd)
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationapplyv1 "k8s.io/client-go/applyconfigurations/admissionregistration/v1"
+	"k8s.io/client-go/kubernetes"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+const messageAcknowledgementCRDName = messagev1.MessageAcknowledgementResourcePlural + "." + messagev1.GroupName
+
+// messageAcknowledgementPolicyName names the admission policy verifying who
+// acknowledges Messages, and its binding.
+const messageAcknowledgementPolicyName = "acknowledged-by." + messageAcknowledgementCRDName
+
+// CreateMessageAcknowledgementCustomResourceDefinition creates the CustomResourceDefinition of
+// MessageAcknowledgements, or updates it if it exists, and waits for it to be established.
+func CreateMessageAcknowledgementCustomResourceDefinition(ctx context.Context, clientset apiextensionsclient.Interface) (*apiextensionsv1.CustomResourceDefinition, error) {
+	schema, err := openAPIV3Schema(reflect.TypeOf(messagev1.MessageAcknowledgement{}).PkgPath() + ".MessageAcknowledgement")
+	if err != nil {
+		return nil, err
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: messageAcknowledgementCRDName,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: messagev1.GroupName,
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     messagev1.MessageAcknowledgementResourcePlural,
+				Singular:   messagev1.MessageAcknowledgementResourceSingular,
+				ShortNames: []string{messagev1.MessageAcknowledgementShortName},
+				Categories: messagev1.MessageAcknowledgementCategories,
+				Kind:       reflect.TypeOf(messagev1.MessageAcknowledgement{}).Name(),
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    messagev1.SchemeGroupVersion.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: schema,
+					},
+					AdditionalPrinterColumns: printerColumns(messagev1.MessageAcknowledgementPrinterColumns),
+				},
+			},
+		},
+	}
+	return applyCustomResourceDefinition(ctx, clientset, crd)
+}
+
+// CreateMessageAcknowledgementAdmissionPolicy creates the admission policy
+// that only admits MessageAcknowledgements whose acknowledgedBy is the user
+// creating them, and never lets it change, along with its binding. It
+// updates them if they exist.
+func CreateMessageAcknowledgementAdmissionPolicy(ctx context.Context, clientset kubernetes.Interface) error {
+	policy := admissionregistrationapplyv1.ValidatingAdmissionPolicy(messageAcknowledgementPolicyName).
+		WithSpec(admissionregistrationapplyv1.ValidatingAdmissionPolicySpec().
+			WithFailurePolicy(admissionregistrationv1.Fail).
+			WithMatchConstraints(admissionregistrationapplyv1.MatchResources().
+				WithResourceRules(admissionregistrationapplyv1.NamedRuleWithOperations().
+					WithAPIGroups(messagev1.GroupName).
+					WithAPIVersions("*").
+					WithResources(messagev1.MessageAcknowledgementResourcePlural).
+					WithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update))).
+			WithValidations(
+				admissionregistrationapplyv1.Validation().
+					WithExpression("request.operation != 'CREATE' || object.spec.acknowledgedBy == request.userInfo.username").
+					WithMessage("spec.acknowledgedBy must be the name of the user creating the MessageAcknowledgement").
+					WithReason(metav1.StatusReasonForbidden),
+				admissionregistrationapplyv1.Validation().
+					WithExpression("request.operation != 'UPDATE' || object.spec.acknowledgedBy == oldObject.spec.acknowledgedBy").
+					WithMessage("spec.acknowledgedBy is immutable").
+					WithReason(metav1.StatusReasonForbidden)))
+	options := metav1.ApplyOptions{FieldManager: FieldManager, Force: true}
+	_, err := clientset.AdmissionregistrationV1().ValidatingAdmissionPolicies().Apply(ctx, policy, options)
+	if err != nil {
+		return err
+	}
+
+	binding := admissionregistrationapplyv1.ValidatingAdmissionPolicyBinding(messageAcknowledgementPolicyName).
+		WithSpec(admissionregistrationapplyv1.ValidatingAdmissionPolicyBindingSpec().
+			WithPolicyName(messageAcknowledgementPolicyName).
+			WithValidationActions(admissionregistrationv1.Deny))
+	_, err = clientset.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Apply(ctx, binding, options)
+	return err
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateMessageAcknowledgementAdmissionPolicy(t *testing.T) {
+	ctx := context.Background()
+	clientset := kubefake.NewClientset()
+	// The second call updates what the first created.
+	for i := 0; i < 2; i++ {
+		if err := CreateMessageAcknowledgementAdmissionPolicy(ctx, clientset); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	policy, err := clientset.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, messageAcknowledgementPolicyName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified := false
+	for _, validation := range policy.Spec.Validations {
+		if strings.Contains(validation.Expression, "object.spec.acknowledgedBy == request.userInfo.username") {
+			verified = true
+		}
+	}
+	if !verified {
+		t.Errorf("policy does not check acknowledgedBy against the user: %+v", policy.Spec.Validations)
+	}
+
+	binding, err := clientset.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(ctx, messageAcknowledgementPolicyName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binding.Spec.PolicyName != policy.ObjectMeta.Name {
+		t.Errorf("binding binds policy %q, want %q", binding.Spec.PolicyName, policy.ObjectMeta.Name)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+const messageTemplateCRDName = messagev1.MessageTemplateResourcePlural + "." + messagev1.GroupName
+
+// CreateMessageTemplateCustomResourceDefinition creates the CustomResourceDefinition of
+// MessageTemplates, or updates it if it exists, and waits for it to be established.
+func CreateMessageTemplateCustomResourceDefinition(ctx context.Context, clientset apiextensionsclient.Interface) (*apiextensionsv1.CustomResourceDefinition, error) {
+	schema, err := openAPIV3Schema(reflect.TypeOf(messagev1.MessageTemplate{}).PkgPath() + ".MessageTemplate")
+	if err != nil {
+		return nil, err
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: messageTemplateCRDName,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: messagev1.GroupName,
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     messagev1.MessageTemplateResourcePlural,
+				Singular:   messagev1.MessageTemplateResourceSingular,
+				ShortNames: []string{messagev1.MessageTemplateShortName},
+				Categories: messagev1.MessageTemplateCategories,
+				Kind:       reflect.TypeOf(messagev1.MessageTemplate{}).Name(),
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    messagev1.SchemeGroupVersion.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: schema,
+					},
+					AdditionalPrinterColumns: printerColumns(messagev1.MessageTemplatePrinterColumns),
+				},
+			},
+		},
+	}
+	return applyCustomResourceDefinition(ctx, clientset, crd)
+}
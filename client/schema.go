@@ -0,0 +1,116 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/yasker/example-crd/pkg/client/openapi"
+)
+
+// objectMetaDefinition is left out of CRD schemas, the API server validates
+// metadata itself and only accepts an empty object schema for it.
+const objectMetaDefinition = "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"
+
+// openAPIV3Schema derives the structural schema of a custom resource from the
+// OpenAPI definition generated for its Go type, so that the CRD never drifts
+// from the types in apis/. References to other definitions are inlined and
+// defaults are dropped, since the Go types carry none of their own.
+func openAPIV3Schema(definition string) (*apiextensionsv1.JSONSchemaProps, error) {
+	defs := openapi.GetOpenAPIDefinitions(func(path string) spec.Ref {
+		return spec.MustCreateRef(path)
+	})
+
+	schema, err := definitionSchema(definition, defs)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	props := &apiextensionsv1.JSONSchemaProps{}
+	if err := json.Unmarshal(data, props); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func definitionSchema(name string, defs map[string]common.OpenAPIDefinition) (map[string]interface{}, error) {
+	if name == objectMetaDefinition {
+		return map[string]interface{}{"type": "object"}, nil
+	}
+	def, ok := defs[name]
+	if !ok {
+		return nil, fmt.Errorf("no OpenAPI definition for %s", name)
+	}
+	data, err := json.Marshal(def.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return inlineSchema(schema, defs)
+}
+
+func inlineSchema(schema map[string]interface{}, defs map[string]common.OpenAPIDefinition) (map[string]interface{}, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := definitionSchema(ref, defs)
+		if err != nil {
+			return nil, err
+		}
+		if description, ok := schema["description"]; ok {
+			resolved["description"] = description
+		}
+		return resolved, nil
+	}
+
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		var err error
+		switch key {
+		case "default":
+			continue
+		case "properties":
+			properties := make(map[string]interface{})
+			for name, property := range value.(map[string]interface{}) {
+				if properties[name], err = inlineSchema(property.(map[string]interface{}), defs); err != nil {
+					return nil, err
+				}
+			}
+			out[key] = properties
+		case "items", "additionalProperties":
+			if nested, ok := value.(map[string]interface{}); ok {
+				if out[key], err = inlineSchema(nested, defs); err != nil {
+					return nil, err
+				}
+			} else {
+				out[key] = value
+			}
+		default:
+			out[key] = value
+		}
+	}
+	return out, nil
+}
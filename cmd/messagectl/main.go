@@ -0,0 +1,336 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command messagectl works with Messages from the command line.
+//
+//	messagectl ack [-n namespace] [-by name] [-comment text] MESSAGE
+//	messagectl deadletters [-n namespace | -A]
+//	messagectl replay [-n namespace] [-keep] DEADLETTER
+//	messagectl watch [-server address] [-ca-file path] [-token token] [-subscriber name] [-n namespace] [-l selector] [-urgency any|urgent|not-urgent] [-resume token] [-ack]
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	broadcastv1 "github.com/yasker/example-crd/apis/broadcast/v1"
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+)
+
+const usage = `Usage: messagectl [-master URL] [-kubeconfig PATH] COMMAND [ARGS]
+
+Commands:
+  ack MESSAGE          acknowledge a Message
+  deadletters          list the DeadLetters of failed Messages
+  replay DEADLETTER    create the failed Message of a DeadLetter again
+  watch                stream Messages from the gRPC broadcast server
+`
+
+func main() {
+	masterURL := flag.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	kubeconfig := flag.String("kubeconfig", os.Getenv("KUBECONFIG"), "Path to a kube config.")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	// watch talks to the broadcast server rather than the API server.
+	if flag.Arg(0) == "watch" {
+		if err := watch(ctx, *masterURL, *kubeconfig, flag.Args()[1:]); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags(*masterURL, *kubeconfig)
+	if err != nil {
+		fail(err)
+	}
+	clientset, err := messageClientset.NewForConfig(config)
+	if err != nil {
+		fail(err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		fail(err)
+	}
+
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "ack":
+		err = ack(ctx, clientset, kubeClient, args)
+	case "deadletters":
+		err = deadLetters(ctx, clientset, args)
+	case "replay":
+		err = replay(ctx, clientset, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "messagectl: %v\n", err)
+	os.Exit(1)
+}
+
+// ack creates a MessageAcknowledgement for the Message named by args.
+func ack(ctx context.Context, clientset messageClientset.Interface, kubeClient kubernetes.Interface, args []string) error {
+	flags := flag.NewFlagSet("ack", flag.ExitOnError)
+	namespace := flags.String("n", "default", "Namespace of the Message.")
+	by := flags.String("by", "", "Who acknowledges the Message. Defaults to the user the API server authenticates, the only one it admits.")
+	comment := flags.String("comment", "", "Comment to record with the acknowledgement.")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("ack takes exactly one Message name")
+	}
+	if *by == "" {
+		review, err := kubeClient.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("finding out who you are, pass -by: %v", err)
+		}
+		*by = review.Status.UserInfo.Username
+	}
+
+	name := flags.Arg(0)
+	// Fail early rather than recording an acknowledgement nothing uses.
+	message, err := clientset.MessageV1().Messages(*namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	acknowledgement := &messagev1.MessageAcknowledgement{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+		},
+		Spec: messagev1.MessageAcknowledgementSpec{
+			MessageRef:     messagev1.MessageReference{Name: name, UID: message.ObjectMeta.UID},
+			AcknowledgedBy: *by,
+			Comment:        *comment,
+		},
+	}
+	result, err := clientset.MessageV1().MessageAcknowledgements(*namespace).Create(ctx, acknowledgement, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Message %s/%s acknowledged by %s (%s)\n", *namespace, name, *by, result.ObjectMeta.Name)
+	return nil
+}
+
+// deadLetters lists DeadLetters with the last error of each.
+func deadLetters(ctx context.Context, clientset messageClientset.Interface, args []string) error {
+	flags := flag.NewFlagSet("deadletters", flag.ExitOnError)
+	namespace := flags.String("n", "default", "Namespace of the DeadLetters.")
+	allNamespaces := flags.Bool("A", false, "List the DeadLetters of all namespaces.")
+	flags.Parse(args)
+	if *allNamespaces {
+		*namespace = metav1.NamespaceAll
+	}
+
+	list, err := clientset.MessageV1().DeadLetters(*namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tMESSAGE\tFAILED\tATTEMPTS\tLAST ERROR")
+	for _, deadLetter := range list.Items {
+		lastError := ""
+		if attempts := deadLetter.Spec.Attempts; len(attempts) != 0 {
+			last := attempts[len(attempts)-1]
+			lastError = last.Sink + ": " + last.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s/%s\t%s ago\t%d\t%s\n",
+			deadLetter.ObjectMeta.Namespace, deadLetter.ObjectMeta.Name,
+			deadLetter.Spec.Message.Namespace, deadLetter.Spec.Message.Name,
+			duration.HumanDuration(time.Since(deadLetter.Spec.FailedAt.Time)),
+			len(deadLetter.Spec.Attempts)+int(deadLetter.Spec.DroppedAttempts), lastError)
+	}
+	return w.Flush()
+}
+
+// replay creates the Message of the DeadLetter named by args again, under
+// a new name, and deletes the DeadLetter unless asked to keep it.
+func replay(ctx context.Context, clientset messageClientset.Interface, args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	namespace := flags.String("n", "default", "Namespace of the DeadLetter.")
+	keep := flags.Bool("keep", false, "Keep the DeadLetter after replaying it.")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("replay takes exactly one DeadLetter name")
+	}
+
+	name := flags.Arg(0)
+	deadLetter, err := clientset.MessageV1().DeadLetters(*namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	original := deadLetter.Spec.Message
+	message := &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: original.Name + "-",
+			Labels:       original.Labels,
+			Annotations: map[string]string{
+				messagev1.ReplayOfAnnotation: *namespace + "/" + name,
+			},
+		},
+		Spec: original.Spec,
+	}
+	result, err := clientset.MessageV1().Messages(original.Namespace).Create(ctx, message, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("DeadLetter %s/%s replayed as Message %s/%s\n", *namespace, name, result.ObjectMeta.Namespace, result.ObjectMeta.Name)
+
+	if *keep {
+		return nil
+	}
+	return clientset.MessageV1().DeadLetters(*namespace).Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(deadLetter.ObjectMeta.UID)),
+	})
+}
+
+// watch prints the Message events streamed by the broadcast server,
+// acknowledging the Messages if asked to. It authenticates with the bearer
+// token of the kube config, unless given one.
+func watch(ctx context.Context, masterURL, kubeconfig string, args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	address := flags.String("server", "localhost:9090", "Address of the gRPC broadcast server.")
+	caFile := flags.String("ca-file", "", "File holding the PEM encoded CA certificates the server certificate is verified with, instead of the system's.")
+	token := flags.String("token", "", "Bearer token to authenticate with. Defaults to the token of the kube config.")
+	subscriber := flags.String("subscriber", "messagectl", "Name of the subscriber, recorded in the status of acknowledged Messages.")
+	namespace := flags.String("n", "", "Namespace of the Messages. Empty watches all namespaces.")
+	selector := flags.String("l", "", "Label selector of the Messages.")
+	urgency := flags.String("urgency", "any", "Urgency of the Messages: any, urgent or not-urgent.")
+	resume := flags.String("resume", "", "Resume token of the last event received.")
+	acknowledge := flags.Bool("ack", false, "Acknowledge every Message received.")
+	flags.Parse(args)
+
+	req := &broadcastv1.WatchRequest{
+		Subscriber:    *subscriber,
+		Namespace:     *namespace,
+		LabelSelector: *selector,
+		ResumeToken:   *resume,
+	}
+	switch *urgency {
+	case "any":
+	case "urgent":
+		req.Urgency = broadcastv1.Urgency_URGENCY_URGENT
+	case "not-urgent":
+		req.Urgency = broadcastv1.Urgency_URGENCY_NOT_URGENT
+	default:
+		return fmt.Errorf("-urgency must be any, urgent or not-urgent")
+	}
+
+	if *token == "" {
+		var err error
+		if *token, err = kubeconfigToken(masterURL, kubeconfig); err != nil {
+			return err
+		}
+	}
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if *caFile != "" {
+		var err error
+		if creds, err = credentials.NewClientTLSFromFile(*caFile, ""); err != nil {
+			return err
+		}
+	}
+	conn, err := grpc.NewClient(*address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(bearerToken(*token)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := broadcastv1.NewBroadcasterClient(conn)
+
+	stream, err := client.Watch(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		message := event.Message
+		fmt.Printf("%s %s/%s (urgent: %v, state: %s, resume: %s): %s\n",
+			strings.TrimPrefix(event.Type.String(), "TYPE_"), message.Namespace, message.Name,
+			message.Urgent, message.State, event.ResumeToken, message.Text)
+		if *acknowledge && event.Type != broadcastv1.MessageEvent_TYPE_DELETED {
+			_, err := client.Ack(ctx, &broadcastv1.AckRequest{
+				Subscriber: *subscriber,
+				Namespace:  message.Namespace,
+				Name:       message.Name,
+				Uid:        message.Uid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// kubeconfigToken returns the bearer token of the kube config.
+func kubeconfigToken(masterURL, kubeconfig string) (string, error) {
+	config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	if config.BearerToken != "" {
+		return config.BearerToken, nil
+	}
+	if config.BearerTokenFile != "" {
+		token, err := os.ReadFile(config.BearerTokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	return "", fmt.Errorf("the kube config has no bearer token, pass one with -token")
+}
+
+// bearerToken sends a bearer token with every call.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
+}
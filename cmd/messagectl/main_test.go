@@ -0,0 +1,93 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/pkg/client/clientset/versioned/fake"
+)
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantErr        bool
+		wantDeadLetter bool
+		wantMessage    bool
+	}{
+		{name: "replay", args: []string{"-n", "dead-letters", "disk-full-0b6c2a1e"}, wantMessage: true},
+		{name: "keep", args: []string{"-n", "dead-letters", "-keep", "disk-full-0b6c2a1e"}, wantDeadLetter: true, wantMessage: true},
+		{name: "missing DeadLetter", args: []string{"-n", "dead-letters", "cpu-hot-0b6c2a1e"}, wantErr: true, wantDeadLetter: true},
+		{name: "no DeadLetter", args: []string{"-n", "dead-letters"}, wantErr: true, wantDeadLetter: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			clientset := fake.NewClientset(&messagev1.DeadLetter{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "dead-letters", Name: "disk-full-0b6c2a1e", UID: "5f0e"},
+				Spec: messagev1.DeadLetterSpec{
+					Message: messagev1.DeadLetterMessage{
+						Namespace: "team-a",
+						Name:      "disk-full",
+						UID:       "0b6c2a1e-4d5f-4e6a-8b7c-9d0e1f2a3b4c",
+						Labels:    map[string]string{"team": "a"},
+						Spec:      messagev1.MessageSpec{Context: "disk /var is 95% full"},
+					},
+				},
+			})
+
+			err := replay(ctx, clientset, test.args)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want one: %v", err, test.wantErr)
+			}
+
+			deadLetters, err := clientset.MessageV1().DeadLetters("dead-letters").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(deadLetters.Items) == 1; got != test.wantDeadLetter {
+				t.Errorf("got DeadLetter kept: %v, want %v", got, test.wantDeadLetter)
+			}
+
+			messages, err := clientset.MessageV1().Messages("team-a").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(messages.Items) == 1; got != test.wantMessage {
+				t.Fatalf("got Message replayed: %v, want %v", got, test.wantMessage)
+			}
+			if !test.wantMessage {
+				return
+			}
+			message := messages.Items[0]
+			if message.ObjectMeta.GenerateName != "disk-full-" {
+				t.Errorf("got generateName %q, want %q", message.ObjectMeta.GenerateName, "disk-full-")
+			}
+			if message.ObjectMeta.Annotations[messagev1.ReplayOfAnnotation] != "dead-letters/disk-full-0b6c2a1e" {
+				t.Errorf("got annotations %v, want the DeadLetter replayed", message.ObjectMeta.Annotations)
+			}
+			if message.ObjectMeta.Labels["team"] != "a" || message.Spec.Context != "disk /var is 95% full" {
+				t.Errorf("got Message %+v, want the Message of the DeadLetter", message)
+			}
+		})
+	}
+}
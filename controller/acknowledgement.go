@@ -0,0 +1,183 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+)
+
+// acknowledgementIndex indexes MessageAcknowledgements by the
+// "<namespace>/<name>" key of the Message they acknowledge.
+const acknowledgementIndex = "message"
+
+func indexByMessage(obj interface{}) ([]string, error) {
+	ack, ok := obj.(*messagev1.MessageAcknowledgement)
+	if !ok {
+		return nil, nil
+	}
+	return []string{ack.ObjectMeta.Namespace + "/" + ack.Spec.MessageRef.Name}, nil
+}
+
+func (c *MessageController) watchAcknowledgements(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().MessageAcknowledgements()
+	err := informer.Informer().AddIndexers(cache.Indexers{acknowledgementIndex: indexByMessage})
+	if err != nil {
+		return err
+	}
+
+	// Deleting an acknowledgement does not take it back, the Message status
+	// keeps the first one.
+	_, err = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onAcknowledgementAdd,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.acknowledgementIndex = informer.Informer().GetIndexer()
+	return nil
+}
+
+func (c *MessageController) onAcknowledgementAdd(obj interface{}) {
+	keys, err := indexByMessage(obj)
+	if err != nil || len(keys) == 0 {
+		return
+	}
+	c.queue.Add(keys[0])
+}
+
+// acknowledgementTimeout returns how long message may go unacknowledged
+// after it was broadcasted, and false if it does not need to be
+// acknowledged at all. A zero timeout waits forever.
+func (c *MessageController) acknowledgementTimeout(message *messagev1.Message) (time.Duration, bool) {
+	policy := message.Spec.Acknowledgement
+	if policy == nil {
+		return c.AcknowledgementTimeout, message.Spec.Urgent && c.AcknowledgementTimeout > 0
+	}
+	if policy.Timeout != nil {
+		return policy.Timeout.Duration, true
+	}
+	return c.AcknowledgementTimeout, true
+}
+
+// acknowledge tracks the acknowledgement of a broadcasted message that needs
+// one, escalating it once it is past its deadline: through its
+// EscalationPolicy if it has one, or else once, to its escalation channel or
+// again through r, the route message was broadcast through. A positive
+// duration is how long until the deadline.
+func (c *MessageController) acknowledge(ctx context.Context, message *messagev1.Message, r route) (time.Duration, error) {
+	timeout, ok := c.acknowledgementTimeout(message)
+	if !ok {
+		return 0, nil
+	}
+
+	status := message.Status.Acknowledgement
+	if status == nil {
+		status = &messagev1.AcknowledgementStatus{State: messagev1.AcknowledgementStatePending}
+		if timeout > 0 {
+			deadline := metav1.NewTime(time.Now().Add(timeout))
+			status.Deadline = &deadline
+		}
+		message.Status.Acknowledgement = status
+	}
+	if status.State == messagev1.AcknowledgementStateAcknowledged {
+		return 0, nil
+	}
+
+	if ack := c.firstAcknowledgement(message); ack != nil {
+		status.State = messagev1.AcknowledgementStateAcknowledged
+		status.AcknowledgedBy = ack.Spec.AcknowledgedBy
+		acknowledgedAt := ack.ObjectMeta.CreationTimestamp
+		if acknowledgedAt.IsZero() {
+			acknowledgedAt = metav1.Now()
+		}
+		status.AcknowledgedAt = &acknowledgedAt
+		resolveEscalation(message, messagev1.EscalationReasonUnacknowledged)
+		return 0, nil
+	}
+	if status.State == messagev1.AcknowledgementStateEscalated {
+		if message.Spec.EscalationPolicyRef != nil {
+			return c.escalateUnacknowledged(ctx, message)
+		}
+		return 0, nil
+	}
+	if status.Deadline == nil {
+		return 0, nil
+	}
+	if remaining := time.Until(status.Deadline.Time); remaining > 0 {
+		return remaining, nil
+	}
+
+	if message.Spec.EscalationPolicyRef != nil {
+		now := metav1.Now()
+		status.State = messagev1.AcknowledgementStateEscalated
+		status.EscalatedAt = &now
+		return c.escalateUnacknowledged(ctx, message)
+	}
+
+	if policy := message.Spec.Acknowledgement; policy != nil && policy.EscalationChannelRef != nil {
+		var err error
+		r, _, err = c.channelRoute(policy.EscalationChannelRef.Name, message.ObjectMeta.Namespace)
+		if err != nil {
+			return 0, fmt.Errorf("escalating: %v", err)
+		}
+	}
+	retryAfter, err := c.escalate(ctx, message, r, "escalation")
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+	now := metav1.Now()
+	status.State = messagev1.AcknowledgementStateEscalated
+	status.EscalatedAt = &now
+	return 0, nil
+}
+
+// firstAcknowledgement returns the earliest MessageAcknowledgement of
+// message, or nil if there is none. An acknowledgement without the UID of
+// the Message it refers to is taken for the Message of that name it does
+// not predate, so that an earlier Message of the same name is not
+// acknowledged again.
+func (c *MessageController) firstAcknowledgement(message *messagev1.Message) *messagev1.MessageAcknowledgement {
+	objs, err := c.acknowledgementIndex.ByIndex(acknowledgementIndex, message.ObjectMeta.Namespace+"/"+message.ObjectMeta.Name)
+	if err != nil {
+		fmt.Printf("ERROR listing MessageAcknowledgements of %s/%s: %v\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name, err)
+		return nil
+	}
+
+	var first *messagev1.MessageAcknowledgement
+	for _, obj := range objs {
+		ack := obj.(*messagev1.MessageAcknowledgement)
+		if uid := ack.Spec.MessageRef.UID; uid != "" && uid != message.ObjectMeta.UID {
+			continue
+		}
+		if ack.Spec.MessageRef.UID == "" && ack.ObjectMeta.CreationTimestamp.Before(&message.ObjectMeta.CreationTimestamp) {
+			continue
+		}
+		if first == nil || ack.ObjectMeta.CreationTimestamp.Before(&first.ObjectMeta.CreationTimestamp) {
+			first = ack
+		}
+	}
+	return first
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+func TestFirstAcknowledgement(t *testing.T) {
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+	message := &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "team-a",
+			Name:              "disk-full",
+			UID:               "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	newAcknowledgement := func(name string, uid types.UID, at time.Time) *messagev1.MessageAcknowledgement {
+		return &messagev1.MessageAcknowledgement{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "team-a",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(at),
+			},
+			Spec: messagev1.MessageAcknowledgementSpec{
+				MessageRef:     messagev1.MessageReference{Name: "disk-full", UID: uid},
+				AcknowledgedBy: "alice",
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		acknowledgements []runtime.Object
+		want             string
+	}{
+		{name: "none"},
+		{
+			name:             "same UID",
+			acknowledgements: []runtime.Object{newAcknowledgement("disk-full-ack", message.ObjectMeta.UID, created.Add(time.Minute))},
+			want:             "disk-full-ack",
+		},
+		{
+			name:             "other UID",
+			acknowledgements: []runtime.Object{newAcknowledgement("disk-full-ack", "0b6c2a1e-4d5f-4e6a-8b7c-9d0e1f2a3b4c", created.Add(time.Minute))},
+		},
+		{
+			name:             "no UID",
+			acknowledgements: []runtime.Object{newAcknowledgement("disk-full-ack", "", created.Add(time.Minute))},
+			want:             "disk-full-ack",
+		},
+		{
+			name:             "no UID, of an earlier Message",
+			acknowledgements: []runtime.Object{newAcknowledgement("disk-full-ack", "", created.Add(-time.Minute))},
+		},
+		{
+			name: "earliest",
+			acknowledgements: []runtime.Object{
+				newAcknowledgement("disk-full-later", message.ObjectMeta.UID, created.Add(2*time.Minute)),
+				newAcknowledgement("disk-full-first", message.ObjectMeta.UID, created.Add(time.Minute)),
+				newAcknowledgement("disk-full-stale", "0b6c2a1e-4d5f-4e6a-8b7c-9d0e1f2a3b4c", created.Add(-time.Minute)),
+			},
+			want: "disk-full-first",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &MessageController{}
+			startController(t, c, append(test.acknowledgements, message)...)
+
+			got := ""
+			if ack := c.firstAcknowledgement(message); ack != nil {
+				got = ack.ObjectMeta.Name
+			}
+			if got != test.want {
+				t.Errorf("got acknowledgement %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yasker/example-crd/metrics"
+	"github.com/yasker/example-crd/sink"
+)
+
+// breakers are the circuit breakers of the sinks. A breaker opens after
+// threshold deliveries to its sink failed in a row. While it is open,
+// deliveries to the sink are parked instead of attempted. After cooldown,
+// it lets one delivery through: if that succeeds, the breaker closes and the
+// parked deliveries resume, otherwise it opens again.
+type breakers struct {
+	lock      sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	sinks     map[string]*breaker
+}
+
+type breaker struct {
+	state    string
+	failures int
+	openedAt time.Time
+	// parked are the keys of the Messages waiting for the breaker to close.
+	parked map[string]struct{}
+}
+
+// SinkStatus is the state of the circuit breaker of a sink.
+type SinkStatus struct {
+	Sink                string     `json:"sink"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	Parked              int        `json:"parked"`
+}
+
+func (b *breakers) get(sinkName string) *breaker {
+	if b.sinks == nil {
+		b.sinks = make(map[string]*breaker)
+	}
+	br, ok := b.sinks[sinkName]
+	if !ok {
+		br = &breaker{parked: make(map[string]struct{})}
+		b.sinks[sinkName] = br
+		b.setState(sinkName, br, metrics.BreakerClosed)
+	}
+	return br
+}
+
+func (b *breakers) setState(sinkName string, br *breaker, state string) {
+	br.state = state
+	for _, s := range metrics.BreakerStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		metrics.BreakerState.WithLabelValues(sinkName, s).Set(value)
+	}
+}
+
+// allow tells whether the Message stored under key may be delivered to the
+// sink called sinkName. If not, the Message is parked until the breaker
+// closes, and the returned duration is when to try again in case the
+// breaker stays open.
+func (b *breakers) allow(sinkName, key string) (time.Duration, bool) {
+	if b.threshold <= 0 {
+		return 0, true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	br := b.get(sinkName)
+	switch br.state {
+	case metrics.BreakerClosed:
+		return 0, true
+	case metrics.BreakerOpen:
+		if remaining := time.Until(br.openedAt.Add(b.cooldown)); remaining > 0 {
+			br.parked[key] = struct{}{}
+			return remaining, false
+		}
+		b.setState(sinkName, br, metrics.BreakerHalfOpen)
+		return 0, true
+	}
+	// A probe is in flight.
+	br.parked[key] = struct{}{}
+	return b.cooldown, false
+}
+
+// record records the outcome of a delivery to the sink called sinkName
+// that allow let through. It returns the keys of the Messages to requeue
+// because the breaker closed.
+func (b *breakers) record(sinkName string, err error) []string {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	br := b.get(sinkName)
+	if err != nil {
+		br.failures++
+		if br.state == metrics.BreakerHalfOpen || br.failures >= b.threshold {
+			br.openedAt = time.Now()
+			b.setState(sinkName, br, metrics.BreakerOpen)
+		}
+		return nil
+	}
+
+	br.failures = 0
+	if br.state == metrics.BreakerClosed {
+		return nil
+	}
+	b.setState(sinkName, br, metrics.BreakerClosed)
+	keys := make([]string, 0, len(br.parked))
+	for key := range br.parked {
+		keys = append(keys, key)
+	}
+	br.parked = make(map[string]struct{})
+	return keys
+}
+
+// release gives up a delivery to the sink called sinkName that allow let
+// through, without attempting it. If it was to probe the sink, the next
+// delivery probes it instead.
+func (b *breakers) release(sinkName string) {
+	if b.threshold <= 0 {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	br := b.get(sinkName)
+	if br.state == metrics.BreakerHalfOpen {
+		b.setState(sinkName, br, metrics.BreakerOpen)
+	}
+}
+
+// recordDelivery records the outcome of a delivery to the sink called
+// sinkName in its circuit breaker, and requeues the Messages parked until it
+// closes.
+func (c *MessageController) recordDelivery(sinkName string, err error) {
+	// A delivery the sink rejected for good still shows it is up.
+	if sink.IsPermanent(err) {
+		err = nil
+	}
+	for _, parked := range c.breakers.record(sinkName, err) {
+		c.queue.Add(parked)
+	}
+}
+
+// report returns the state of the breakers of every sink delivered to so
+// far, ordered by sink name.
+func (b *breakers) report() []SinkStatus {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	report := make([]SinkStatus, 0, len(b.sinks))
+	for name, br := range b.sinks {
+		status := SinkStatus{
+			Sink:                name,
+			State:               br.state,
+			ConsecutiveFailures: br.failures,
+			Parked:              len(br.parked),
+		}
+		if br.state != metrics.BreakerClosed {
+			openedAt := br.openedAt
+			status.OpenedAt = &openedAt
+		}
+		report = append(report, status)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Sink < report[j].Sink })
+	return report
+}
+
+// SinkStatusHandler serves the state of the circuit breakers of the sinks
+// as JSON.
+func (c *MessageController) SinkStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.breakers.report())
+	})
+}
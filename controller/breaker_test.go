@@ -0,0 +1,115 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/sink"
+)
+
+func TestBreakersPerSubscription(t *testing.T) {
+	b := breakers{threshold: 1, cooldown: time.Hour}
+	teamA := sink.SubscriptionName(&messagev1.Subscription{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "oncall"}})
+	teamB := sink.SubscriptionName(&messagev1.Subscription{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "oncall"}})
+
+	b.record(teamA, errors.New("connection refused"))
+	if _, ok := b.allow(teamA, "team-a/disk-full"); ok {
+		t.Error("breaker of the team-a Subscription is closed after it failed")
+	}
+	if _, ok := b.allow(teamB, "team-b/disk-full"); !ok {
+		t.Error("breaker of the team-b Subscription opened on a failure of the team-a one")
+	}
+}
+
+func TestEscalateThroughBreaker(t *testing.T) {
+	c := &MessageController{BreakerThreshold: 1, BreakerCooldown: time.Hour}
+	startController(t, c)
+	s := &testSink{name: "pager", err: errors.New("connection refused")}
+	r := route{sinks: []sink.Sink{s}}
+	message := &messagev1.Message{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "disk-full"}}
+
+	if _, err := c.escalate(context.Background(), message, r, "escalation"); err == nil {
+		t.Fatal("escalating to a failing sink succeeded")
+	}
+	parkedFor, err := c.escalate(context.Background(), message, r, "escalation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parkedFor <= 0 {
+		t.Errorf("escalation was not parked by the open breaker")
+	}
+	if len(s.deliveries) != 1 {
+		t.Errorf("got %d deliveries, want 1 before the breaker opened", len(s.deliveries))
+	}
+}
+
+func TestEscalateThrottled(t *testing.T) {
+	limit, err := ParseRateLimit("1/1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		breakerOpen bool
+		wantTokens  float64
+	}{
+		// The first escalation takes the only token, so the second waits.
+		{name: "throttled"},
+		// The open breaker parks both escalations, which leaves the token.
+		{name: "breaker open", breakerOpen: true, wantTokens: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &MessageController{
+				RateLimits:       RateLimits{Global: limit},
+				BreakerThreshold: 1,
+				BreakerCooldown:  time.Hour,
+			}
+			startController(t, c)
+			s := &testSink{name: "pager"}
+			if test.breakerOpen {
+				c.breakers.record(s.name, errors.New("connection refused"))
+			}
+			r := route{sinks: []sink.Sink{s}}
+			message := &messagev1.Message{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "disk-full"}}
+
+			if _, err := c.escalate(context.Background(), message, r, "escalation"); err != nil {
+				t.Fatal(err)
+			}
+			retryAfter, err := c.escalate(context.Background(), message, r, "escalation")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if retryAfter <= 0 {
+				t.Error("escalation was neither throttled nor parked")
+			}
+			if tokens := c.throttle.global.Tokens(); tokens < test.wantTokens-0.01 {
+				t.Errorf("got %.2f tokens left, want %.0f", tokens, test.wantTokens)
+			}
+			if want := 1 - int(test.wantTokens); len(s.deliveries) != want {
+				t.Errorf("got %d deliveries, want %d", len(s.deliveries), want)
+			}
+		})
+	}
+}
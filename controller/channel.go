@@ -0,0 +1,217 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+	"github.com/yasker/example-crd/sink"
+)
+
+// channelIndex indexes Messages by the name of the BroadcastChannel they
+// reference, so that a channel change requeues the Messages using it.
+const channelIndex = "channel"
+
+func indexByChannel(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.ChannelRef == nil {
+		return nil, nil
+	}
+	return []string{message.Spec.ChannelRef.Name}, nil
+}
+
+func (c *MessageController) watchChannels(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().BroadcastChannels()
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onChannelChange,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.onChannelChange(newObj)
+		},
+		DeleteFunc: c.onChannelChange,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.channelLister = informer.Lister()
+	return nil
+}
+
+func (c *MessageController) onChannelChange(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		fmt.Printf("ERROR getting key for %#v: %v\n", obj, err)
+		return
+	}
+	messages, err := c.messageIndex.ByIndex(channelIndex, key)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages of BroadcastChannel %s: %v\n", key, err)
+		return
+	}
+	for _, message := range messages {
+		c.enqueue(message)
+	}
+}
+
+// route is where a Message gets delivered, and the rate limit of the
+// channel it goes through. errs are why the sinks of some of its recipients
+// could not be built; the Message is not Broadcasted until they are.
+type route struct {
+	sinks   []sink.Sink
+	limiter *rate.Limiter
+	errs    []error
+}
+
+// route resolves where message gets delivered. For a channel, the outcome
+// is recorded in the ChannelResolved condition of message. It returns false
+// if message can't be delivered until its channel changes.
+func (c *MessageController) route(message *messagev1.Message) (route, bool) {
+	if message.Spec.Topic != "" {
+		return c.topicRoute(message), true
+	}
+	if message.Spec.ChannelRef == nil {
+		return route{sinks: c.Sinks}, true
+	}
+
+	name := message.Spec.ChannelRef.Name
+	r, reason, err := c.channelRoute(name, message.ObjectMeta.Namespace)
+	if err != nil {
+		setCondition(message, messagev1.MessageConditionChannelResolved, metav1.ConditionFalse, reason, err.Error())
+		return route{}, false
+	}
+
+	setCondition(message, messagev1.MessageConditionChannelResolved, metav1.ConditionTrue,
+		messagev1.MessageReasonChannelResolved, fmt.Sprintf("Broadcasting through BroadcastChannel %s", name))
+	return r, true
+}
+
+// channelRoute returns the route through the BroadcastChannel called name
+// for a Message in namespace. On error, it also returns the ChannelResolved
+// condition reason describing it.
+func (c *MessageController) channelRoute(name, namespace string) (route, string, error) {
+	channel, err := c.channelLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return route{}, messagev1.MessageReasonChannelNotFound, fmt.Errorf("BroadcastChannel %s does not exist", name)
+	}
+	if err == nil && !channelAllows(channel, namespace) {
+		return route{}, messagev1.MessageReasonChannelForbidden, fmt.Errorf("BroadcastChannel %s does not allow namespace %s", name, namespace)
+	}
+	var r route
+	if err == nil {
+		r, err = c.routes.get("channel/"+name, channel, func() (route, error) {
+			s, err := sink.ForChannel(channel, c.getSecret)
+			if err != nil {
+				return route{}, err
+			}
+			return route{sinks: []sink.Sink{s}, limiter: newLimiter(channel.Spec.RateLimit)}, nil
+		})
+	}
+	if err != nil {
+		return route{}, messagev1.MessageReasonChannelInvalid, err
+	}
+	return r, "", nil
+}
+
+func channelAllows(channel *messagev1.BroadcastChannel, namespace string) bool {
+	if len(channel.Spec.AllowedNamespaces) == 0 {
+		return true
+	}
+	for _, allowed := range channel.Spec.AllowedNamespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// routeCache caches the route of each BroadcastChannel and Subscription, so
+// that sinks are not rebuilt and rate limiters keep their tokens across
+// Messages. Entries are rebuilt when the object's generation changes.
+type routeCache struct {
+	lock    sync.Mutex
+	entries map[string]cachedRoute
+}
+
+type cachedRoute struct {
+	uid        types.UID
+	generation int64
+	route      route
+}
+
+func (rc *routeCache) get(key string, obj metav1.Object, build func() (route, error)) (route, error) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	if entry, ok := rc.entries[key]; ok && entry.uid == obj.GetUID() && entry.generation == obj.GetGeneration() {
+		return entry.route, nil
+	}
+
+	r, err := build()
+	if err != nil {
+		return route{}, err
+	}
+	if rc.entries == nil {
+		rc.entries = make(map[string]cachedRoute)
+	}
+	if entry, ok := rc.entries[key]; ok {
+		closeSinks(entry.route.sinks)
+	}
+	rc.entries[key] = cachedRoute{
+		uid:        obj.GetUID(),
+		generation: obj.GetGeneration(),
+		route:      r,
+	}
+	return r, nil
+}
+
+// closeSinks closes the sinks that hold connections. Deliveries still
+// running on them fail and are retried on the sinks replacing them.
+func closeSinks(sinks []sink.Sink) {
+	for _, s := range sinks {
+		if closer, ok := s.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				fmt.Printf("ERROR closing sink %s: %v\n", s.Name(), err)
+			}
+		}
+	}
+}
+
+func newLimiter(limit *messagev1.RateLimit) *rate.Limiter {
+	if limit == nil || limit.Limit <= 0 {
+		return nil
+	}
+	period := time.Second
+	if limit.Period != nil && limit.Period.Duration > 0 {
+		period = limit.Period.Duration
+	}
+	burst := int(limit.Burst)
+	if burst <= 0 {
+		burst = int(limit.Limit)
+	}
+	return rate.NewLimiter(rate.Limit(float64(limit.Limit)/period.Seconds()), burst)
+}
@@ -19,101 +19,625 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
-	apiv1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/rest"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/metrics"
+	messageapplyv1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+	listers "github.com/yasker/example-crd/pkg/client/listers/message/v1"
+	"github.com/yasker/example-crd/sink"
 )
 
-// Watcher is an example of watching on resource create/update/delete events
+const (
+	// maxRetries is the number of times a Message will be retried before it is
+	// dropped out of the queue until the next resync.
+	maxRetries = 5
+
+	// FieldManager is the field manager the controller applies Message
+	// status with, so that it only ever owns the status fields it sets.
+	FieldManager = "message-controller"
+)
+
+// MessageController broadcasts Message objects to its sinks and keeps their
+// status in line with the recorded deliveries.
 type MessageController struct {
-	MessageClient *rest.RESTClient
-	MessageScheme *runtime.Scheme
+	MessageClient messageClientset.Interface
+
+	// KubeClient reads the Secrets sinks are configured with. Sinks that
+	// need a Secret fail to build without it.
+	KubeClient kubernetes.Interface
+
+	// ResyncPeriod is how often every Message is reconciled again even if
+	// nothing changed, so that lost or hand-edited status gets repaired.
+	// Set to 0 to disable the resync.
+	ResyncPeriod time.Duration
+
+	// Sinks are the destinations Messages without a channelRef are
+	// broadcast to.
+	Sinks []sink.Sink
+
+	// Workers is how many Messages are reconciled in parallel. Defaults to
+	// one.
+	Workers int
+
+	// DedupWindow is how long after a Message another one with the same
+	// idempotency key is suppressed as its duplicate, unless the channel
+	// of the Messages sets its own. Set to 0 to not suppress duplicates.
+	DedupWindow time.Duration
+
+	// RateLimits throttle the deliveries of the controller.
+	RateLimits RateLimits
+
+	// BreakerThreshold is how many deliveries to a sink fail in a row
+	// before its circuit breaker opens. Set to 0 to disable the breakers.
+	BreakerThreshold int
+	// BreakerCooldown is how long a circuit breaker stays open before it
+	// lets a delivery through to probe the sink.
+	BreakerCooldown time.Duration
+
+	// MaxAttempts is how many times delivering a Message to a sink may
+	// fail before the Message is given up on and dead-lettered. Set to 0
+	// to retry forever.
+	MaxAttempts int
+	// DeadLetterNamespace is the namespace DeadLetters are created in.
+	// Defaults to the namespace of the failed Message.
+	DeadLetterNamespace string
+
+	// AcknowledgementTimeout is how long an urgent Message may go without
+	// being acknowledged before it is escalated, unless the Message sets
+	// its own. Set to 0 to not track acknowledgements of urgent Messages.
+	AcknowledgementTimeout time.Duration
+
+	// EventHandlers are added to the Message informer, so that other
+	// components see Messages through the controller's cache instead of a
+	// watch of their own.
+	EventHandlers []cache.ResourceEventHandler
+
+	messageLister          listers.MessageLister
+	messageIndex           cache.Indexer
+	channelLister          listers.BroadcastChannelLister
+	subscriptionIndex      cache.Indexer
+	templateLister         listers.MessageTemplateLister
+	acknowledgementIndex   cache.Indexer
+	escalationPolicyLister listers.EscalationPolicyLister
+	routes                 routeCache
+	throttle               throttle
+	breakers               breakers
+
+	queue workqueue.TypedRateLimitingInterface[string]
 }
 
 // Run starts an Message resource controller
 func (c *MessageController) Run(ctx context.Context) error {
 	fmt.Print("Watch Message objects\n")
 
-	// Watch Message objects
-	_, err := c.watchMessages(ctx)
-	if err != nil {
-		fmt.Printf("Failed to register watch for Message resource: %v\n", err)
+	c.queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	defer c.queue.ShutDown()
+
+	// resyncPeriod
+	// Every resyncPeriod, all resources in the cache will retrigger events.
+	// Set to 0 to disable the resync.
+	factory := informers.NewSharedInformerFactory(c.MessageClient, c.ResyncPeriod)
+	if err := c.setUp(factory); err != nil {
 		return err
 	}
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	for informer, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("timed out waiting for %v cache to sync", informer)
+		}
+	}
+
+	workers := c.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() { c.runWorker(ctx) }, time.Second, ctx.Done())
+	}
 
 	<-ctx.Done()
 	return ctx.Err()
 }
 
-func (c *MessageController) watchMessages(ctx context.Context) (cache.Controller, error) {
-	source := cache.NewListWatchFromClient(
-		c.MessageClient,
-		messagev1.MessageResourcePlural,
-		apiv1.NamespaceAll,
-		fields.Everything())
-
-	_, controller := cache.NewInformer(
-		source,
+// setUp creates the limits of the controller, and registers its informers
+// with factory.
+func (c *MessageController) setUp(factory informers.SharedInformerFactory) error {
+	c.throttle.global = newLimiter(c.RateLimits.Global)
+	c.breakers.threshold = c.BreakerThreshold
+	c.breakers.cooldown = c.BreakerCooldown
 
-		// The object type.
-		&messagev1.Message{},
+	if err := c.watchMessages(factory); err != nil {
+		fmt.Printf("Failed to register watch for Message resource: %v\n", err)
+		return err
+	}
+	if err := c.watchChannels(factory); err != nil {
+		fmt.Printf("Failed to register watch for BroadcastChannel resource: %v\n", err)
+		return err
+	}
+	if err := c.watchSubscriptions(factory); err != nil {
+		fmt.Printf("Failed to register watch for Subscription resource: %v\n", err)
+		return err
+	}
+	if err := c.watchTemplates(factory); err != nil {
+		fmt.Printf("Failed to register watch for MessageTemplate resource: %v\n", err)
+		return err
+	}
+	if err := c.watchAcknowledgements(factory); err != nil {
+		fmt.Printf("Failed to register watch for MessageAcknowledgement resource: %v\n", err)
+		return err
+	}
+	if err := c.watchEscalationPolicies(factory); err != nil {
+		fmt.Printf("Failed to register watch for EscalationPolicy resource: %v\n", err)
+		return err
+	}
+	return nil
+}
 
-		// resyncPeriod
-		// Every resyncPeriod, all resources in the cache will retrigger events.
-		// Set to 0 to disable the resync.
-		0,
+func (c *MessageController) watchMessages(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().Messages()
+	err := informer.Informer().AddIndexers(cache.Indexers{
+		channelIndex:          indexByChannel,
+		topicIndex:            indexMessageByTopic,
+		templateIndex:         indexByTemplate,
+		escalationPolicyIndex: indexByEscalationPolicy,
+		ownerIndex:            indexByOwner,
+		idempotencyIndex:      indexByIdempotencyKey,
+		orderingIndex:         indexByOrderingKey,
+	})
+	if err != nil {
+		return err
+	}
 
-		// Your custom resource event handlers.
-		cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.onAdd,
-			UpdateFunc: c.onUpdate,
-			DeleteFunc: c.onDelete,
-		})
+	// Your custom resource event handlers.
+	_, err = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdd,
+		UpdateFunc: c.onUpdate,
+		DeleteFunc: c.onDelete,
+	})
+	if err != nil {
+		return err
+	}
+	for _, handler := range c.EventHandlers {
+		if _, err := informer.Informer().AddEventHandler(handler); err != nil {
+			return err
+		}
+	}
 
-	go controller.Run(ctx.Done())
-	return controller, nil
+	c.messageLister = informer.Lister()
+	c.messageIndex = informer.Informer().GetIndexer()
+	return nil
 }
 
 func (c *MessageController) onAdd(obj interface{}) {
 	message := obj.(*messagev1.Message)
-	fmt.Printf("[CONTROLLER] OnAdd %s\n", message.ObjectMeta.SelfLink)
+	fmt.Printf("[CONTROLLER] OnAdd %s/%s\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name)
+	c.enqueue(obj)
+}
+
+// onUpdate is also called for every Message on each resync, with oldObj and
+// newObj being the same, so reconciling here is what repairs drift.
+func (c *MessageController) onUpdate(oldObj, newObj interface{}) {
+	oldMessage := oldObj.(*messagev1.Message)
+	newMessage := newObj.(*messagev1.Message)
+	fmt.Printf("[CONTROLLER] OnUpdate oldObj: %s/%s@%s\n", oldMessage.ObjectMeta.Namespace, oldMessage.ObjectMeta.Name, oldMessage.ObjectMeta.ResourceVersion)
+	fmt.Printf("[CONTROLLER] OnUpdate newObj: %s/%s@%s\n", newMessage.ObjectMeta.Namespace, newMessage.ObjectMeta.Name, newMessage.ObjectMeta.ResourceVersion)
+	c.enqueue(newObj)
+	c.enqueueSuccessors(oldMessage, newMessage)
+}
+
+func (c *MessageController) onDelete(obj interface{}) {
+	if message, ok := obj.(*messagev1.Message); ok {
+		fmt.Printf("[CONTROLLER] OnDelete %s/%s\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name)
+	}
+	c.enqueue(obj)
+}
+
+func (c *MessageController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		fmt.Printf("ERROR getting key for %#v: %v\n", obj, err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *MessageController) runWorker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *MessageController) processNextItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeueAfter, err := c.syncMessage(ctx, key)
+	if err == nil {
+		c.queue.Forget(key)
+		if requeueAfter > 0 {
+			c.queue.AddAfter(key, requeueAfter)
+		}
+		return true
+	}
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		fmt.Printf("ERROR syncing Message %v, retrying: %v\n", key, err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	fmt.Printf("ERROR syncing Message %v, dropping it until the next resync: %v\n", key, err)
+	c.queue.Forget(key)
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return true
+}
+
+// syncMessage reconciles the Message stored under key. A positive duration
+// asks for the Message to be reconciled again after that long.
+func (c *MessageController) syncMessage(ctx context.Context, key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, err
+	}
+	message, err := c.messageLister.Messages(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		fmt.Printf("[CONTROLLER] Message %s is gone\n", key)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
 
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	// You can use DeepCopy() to make a deep copy of original object and modify this copy
 	// Or create a copy manually for better performance
 	messageCopy := message.DeepCopy()
-	messageCopy.Status = messagev1.MessageStatus{
-		State: messagev1.MessageStateBroadcasted,
+	announced := message.Status
+	if announced.State == "" {
+		// reconcile may deliver the broadcast, so the creation is announced
+		// before it.
+		created := message.DeepCopy()
+		created.Status.State = messagev1.MessageStateCreated
+		c.announce(ctx, created, []string{sink.EventCreated})
+		announced.State = messagev1.MessageStateCreated
 	}
+	requeueAfter, reconcileErr := c.reconcile(ctx, messageCopy)
 
-	err := c.MessageClient.Put().
-		Name(message.ObjectMeta.Name).
-		Namespace(message.ObjectMeta.Namespace).
-		Resource(messagev1.MessageResourcePlural).
-		Body(messageCopy).
-		Do().
-		Error()
+	if equality.Semantic.DeepEqual(message.Status, messageCopy.Status) {
+		return requeueAfter, reconcileErr
+	}
 
+	_, err = c.MessageClient.MessageV1().Messages(message.ObjectMeta.Namespace).ApplyStatus(ctx,
+		statusApplyConfiguration(messageCopy),
+		metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
 	if err != nil {
 		fmt.Printf("ERROR updating status: %v\n", err)
-	} else {
-		fmt.Printf("UPDATED status: %#v\n", messageCopy)
+		return 0, utilerrors.NewAggregate([]error{reconcileErr, err})
 	}
+	fmt.Printf("UPDATED status: %#v\n", messageCopy)
+	c.announce(ctx, messageCopy, sink.Events(&announced, &messageCopy.Status))
+	return requeueAfter, reconcileErr
 }
 
-func (c *MessageController) onUpdate(oldObj, newObj interface{}) {
-	oldMessage := oldObj.(*messagev1.Message)
-	newMessage := newObj.(*messagev1.Message)
-	fmt.Printf("[CONTROLLER] OnUpdate oldObj: %s\n", oldMessage.ObjectMeta.SelfLink)
-	fmt.Printf("[CONTROLLER] OnUpdate newObj: %s\n", newMessage.ObjectMeta.SelfLink)
+// announce delivers events, changes of the status of message, to the sinks
+// of message that deliver events. It is best effort: events held back by a
+// circuit breaker or a rate limit are dropped, and failed deliveries are
+// only logged, since the status already records the change.
+func (c *MessageController) announce(ctx context.Context, message *messagev1.Message, events []string) {
+	if len(events) == 0 {
+		return
+	}
+	// Routing records conditions, which are already written.
+	r, ok := c.route(message.DeepCopy())
+	if !ok {
+		return
+	}
+
+	key := message.ObjectMeta.Namespace + "/" + message.ObjectMeta.Name
+	for _, s := range r.sinks {
+		if eventSink, ok := s.(sink.EventSink); !ok || !eventSink.DeliversEvents() {
+			continue
+		}
+		for _, event := range events {
+			if _, ok := c.breakers.allow(s.Name(), key); !ok {
+				break
+			}
+			if delay, _ := c.reserve(message, r, s.Name()); delay > 0 {
+				c.breakers.release(s.Name())
+				break
+			}
+			err := s.Deliver(ctx, sink.NewEventDelivery(message, s.Name(), event))
+			c.recordDelivery(s.Name(), err)
+			if err != nil {
+				fmt.Printf("ERROR announcing %s of Message %s to sink %s: %v\n", event, key, s.Name(), err)
+			}
+		}
+	}
 }
 
-func (c *MessageController) onDelete(obj interface{}) {
-	message := obj.(*messagev1.Message)
-	fmt.Printf("[CONTROLLER] OnDelete %s\n", message.ObjectMeta.SelfLink)
+// reconcile compares the desired state of message, delivered to every sink
+// it is routed to and Broadcasted, with what its status records, and
+// repairs the status and any missing deliveries in place.
+func (c *MessageController) reconcile(ctx context.Context, message *messagev1.Message) (time.Duration, error) {
+	status := &message.Status
+	broadcasted := status.State == messagev1.MessageStateBroadcasted
+	// Status is not accepted on create, so new Messages come without a state.
+	if status.State == "" {
+		status.State = messagev1.MessageStateCreated
+	}
+
+	if message.Spec.Schedule != nil {
+		return c.schedule(ctx, message)
+	}
+	if deliverAt := message.Spec.DeliverAt; deliverAt != nil && !broadcasted {
+		// The queue keeps a timer for the Message rather than polling it.
+		if remaining := time.Until(deliverAt.Time); remaining > 0 {
+			status.State = messagev1.MessageStateScheduled
+			return remaining, nil
+		}
+	}
+
+	if status.State == messagev1.MessageStateSuppressed || status.State == messagev1.MessageStateFailed {
+		return 0, nil
+	}
+	if original := c.duplicated(message); original != nil {
+		fmt.Printf("[CONTROLLER] Suppressing Message %s/%s, a duplicate of %s\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name, original.ObjectMeta.Name)
+		status.State = messagev1.MessageStateSuppressed
+		status.DuplicateOf = &messagev1.MessageReference{Name: original.ObjectMeta.Name, UID: original.ObjectMeta.UID}
+		return 0, nil
+	}
+
+	if !c.render(message) {
+		return 0, nil
+	}
+	if c.blocked(message) {
+		return 0, nil
+	}
+
+	route, ok := c.route(message)
+	if !ok {
+		return 0, nil
+	}
+
+	key := message.ObjectMeta.Namespace + "/" + message.ObjectMeta.Name
+	var errs []error
+	var parkedFor time.Duration
+	permanent := false
+	pending := 0
+	for _, s := range route.sinks {
+		delivery := findDelivery(status, s.Name())
+		if delivery != nil && delivery.State == messagev1.DeliveryStateDelivered {
+			continue
+		}
+		// A delivery the breaker parks takes no rate limit tokens.
+		if delay, ok := c.breakers.allow(s.Name(), key); !ok {
+			setDelivery(status, messagev1.MessageDelivery{
+				Sink:     s.Name(),
+				State:    messagev1.DeliveryStateParked,
+				Error:    fmt.Sprintf("circuit breaker of sink %s is open", s.Name()),
+				Attempts: deliveryAttempts(delivery),
+			})
+			if parkedFor == 0 || delay < parkedFor {
+				parkedFor = delay
+			}
+			continue
+		}
+		if delay, scope := c.reserve(message, route, s.Name()); delay > 0 {
+			c.breakers.release(s.Name())
+			fmt.Printf("[CONTROLLER] Rate limited %s/%s by the %s rate limit, retrying in %v\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name, scope, delay)
+			setThrottled(message, scope, delay)
+			return delay, nil
+		}
+		clearThrottled(message)
+		pending++
+		if broadcasted {
+			metrics.DriftRepairs.WithLabelValues(metrics.DriftDelivery).Inc()
+		}
+
+		err := s.Deliver(ctx, sink.NewDelivery(message, s.Name(), ""))
+		c.recordDelivery(s.Name(), err)
+		if sink.IsPermanent(err) {
+			permanent = true
+		}
+		if err != nil {
+			setDelivery(status, messagev1.MessageDelivery{
+				Sink:     s.Name(),
+				State:    messagev1.DeliveryStateFailed,
+				Error:    err.Error(),
+				Attempts: deliveryAttempts(delivery) + 1,
+			})
+			recordFailedAttempt(status, s.Name(), err)
+			errs = append(errs, fmt.Errorf("sink %s: %v", s.Name(), err))
+			continue
+		}
+		now := metav1.Now()
+		setDelivery(status, messagev1.MessageDelivery{
+			Sink:        s.Name(),
+			State:       messagev1.DeliveryStateDelivered,
+			DeliveredAt: &now,
+		})
+	}
+	// The queue retries, with backoff, until the sinks of every recipient
+	// are built.
+	errs = append(errs, route.errs...)
+	if len(errs) != 0 {
+		if permanent || c.exhausted(status) {
+			return 0, c.deadLetter(ctx, message)
+		}
+		return c.escalateUndelivered(ctx, message, utilerrors.NewAggregate(errs))
+	}
+	if parkedFor > 0 {
+		fmt.Printf("[CONTROLLER] Parked %s/%s until the circuit breakers of its sinks close\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name)
+		requeueAfter, err := c.escalateUndelivered(ctx, message, nil)
+		if requeueAfter == 0 || parkedFor < requeueAfter {
+			requeueAfter = parkedFor
+		}
+		return requeueAfter, err
+	}
+	resolveEscalation(message, messagev1.EscalationReasonUndelivered)
+
+	if !broadcasted {
+		if pending == 0 && len(route.sinks) != 0 {
+			metrics.DriftRepairs.WithLabelValues(metrics.DriftState).Inc()
+		}
+		status.State = messagev1.MessageStateBroadcasted
+	}
+	return c.acknowledge(ctx, message, route)
+}
+
+func findDelivery(status *messagev1.MessageStatus, sinkName string) *messagev1.MessageDelivery {
+	for i := range status.Deliveries {
+		if status.Deliveries[i].Sink == sinkName {
+			return &status.Deliveries[i]
+		}
+	}
+	return nil
+}
+
+// getSecret reads a Secret sinks are configured with.
+func (c *MessageController) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if c.KubeClient == nil {
+		return nil, fmt.Errorf("no Kubernetes client to read Secret %s/%s with", namespace, name)
+	}
+	return c.KubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func deliveryAttempts(delivery *messagev1.MessageDelivery) int32 {
+	if delivery == nil {
+		return 0
+	}
+	return delivery.Attempts
+}
+
+func setDelivery(status *messagev1.MessageStatus, delivery messagev1.MessageDelivery) {
+	if existing := findDelivery(status, delivery.Sink); existing != nil {
+		*existing = delivery
+		return
+	}
+	status.Deliveries = append(status.Deliveries, delivery)
+}
+
+// setCondition sets a condition of message, keeping the transition time
+// when its status did not change.
+func setCondition(message *messagev1.Message, conditionType string, status metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&message.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: message.ObjectMeta.Generation,
+	})
+}
+
+// statusApplyConfiguration builds the apply configuration holding the full
+// status of message as the controller wants it.
+func statusApplyConfiguration(message *messagev1.Message) *messageapplyv1.MessageApplyConfiguration {
+	status := messageapplyv1.MessageStatus().WithState(message.Status.State)
+	if message.Status.Subscribers != 0 {
+		status.WithSubscribers(message.Status.Subscribers)
+	}
+	if message.Status.Rendered != "" {
+		status.WithRendered(message.Status.Rendered)
+	}
+	for _, d := range message.Status.Deliveries {
+		delivery := messageapplyv1.MessageDelivery().
+			WithSink(d.Sink).
+			WithState(d.State)
+		if d.Attempts != 0 {
+			delivery.WithAttempts(d.Attempts)
+		}
+		if d.Error != "" {
+			delivery.WithError(d.Error)
+		}
+		if d.DeliveredAt != nil {
+			delivery.WithDeliveredAt(*d.DeliveredAt)
+		}
+		status.WithDeliveries(delivery)
+	}
+	for _, a := range message.Status.FailedAttempts {
+		status.WithFailedAttempts(messageapplyv1.DeliveryAttempt().
+			WithSink(a.Sink).
+			WithError(a.Error).
+			WithTime(a.Time))
+	}
+	if message.Status.DroppedAttempts != 0 {
+		status.WithDroppedAttempts(message.Status.DroppedAttempts)
+	}
+	if message.Status.DuplicateOf != nil {
+		duplicateOf := messageapplyv1.MessageReference().WithName(message.Status.DuplicateOf.Name)
+		if message.Status.DuplicateOf.UID != "" {
+			duplicateOf.WithUID(message.Status.DuplicateOf.UID)
+		}
+		status.WithDuplicateOf(duplicateOf)
+	}
+	if ack := message.Status.Acknowledgement; ack != nil {
+		acknowledgement := messageapplyv1.AcknowledgementStatus().WithState(ack.State)
+		if ack.Deadline != nil {
+			acknowledgement.WithDeadline(*ack.Deadline)
+		}
+		if ack.AcknowledgedBy != "" {
+			acknowledgement.WithAcknowledgedBy(ack.AcknowledgedBy)
+		}
+		if ack.AcknowledgedAt != nil {
+			acknowledgement.WithAcknowledgedAt(*ack.AcknowledgedAt)
+		}
+		if ack.EscalatedAt != nil {
+			acknowledgement.WithEscalatedAt(*ack.EscalatedAt)
+		}
+		status.WithAcknowledgement(acknowledgement)
+	}
+	if esc := message.Status.Escalation; esc != nil {
+		escalation := messageapplyv1.EscalationStatus().
+			WithPolicy(esc.Policy).
+			WithReason(esc.Reason).
+			WithState(esc.State).
+			WithStep(esc.Step)
+		if esc.Repeats != 0 {
+			escalation.WithRepeats(esc.Repeats)
+		}
+		if esc.NextAt != nil {
+			escalation.WithNextAt(*esc.NextAt)
+		}
+		if esc.LastEscalatedAt != nil {
+			escalation.WithLastEscalatedAt(*esc.LastEscalatedAt)
+		}
+		status.WithEscalation(escalation)
+	}
+	if message.Status.LastScheduleTime != nil {
+		status.WithLastScheduleTime(*message.Status.LastScheduleTime)
+	}
+	if message.Status.NextScheduleTime != nil {
+		status.WithNextScheduleTime(*message.Status.NextScheduleTime)
+	}
+	for _, c := range message.Status.Conditions {
+		status.WithConditions(metav1apply.Condition().
+			WithType(c.Type).
+			WithStatus(c.Status).
+			WithReason(c.Reason).
+			WithMessage(c.Message).
+			WithObservedGeneration(c.ObservedGeneration).
+			WithLastTransitionTime(c.LastTransitionTime))
+	}
+	return messageapplyv1.Message(message.ObjectMeta.Name, message.ObjectMeta.Namespace).WithStatus(status)
 }
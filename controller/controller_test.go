@@ -0,0 +1,469 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/pkg/client/clientset/versioned/fake"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+	"github.com/yasker/example-crd/sink"
+)
+
+// testSink records the deliveries made to it, and fails them with err.
+type testSink struct {
+	name       string
+	err        error
+	deliveries []*sink.Delivery
+}
+
+func (s *testSink) Name() string {
+	return s.name
+}
+
+func (s *testSink) Deliver(ctx context.Context, delivery *sink.Delivery) error {
+	s.deliveries = append(s.deliveries, delivery)
+	return s.err
+}
+
+// eventSink is a testSink that wants the events of status changes.
+type eventSink struct {
+	testSink
+}
+
+func (s *eventSink) DeliversEvents() bool {
+	return true
+}
+
+// startController sets c up as Run does, against a fake clientset holding
+// objects, and waits for its caches to fill, without starting workers.
+func startController(t *testing.T, c *MessageController, objects ...runtime.Object) *fake.Clientset {
+	t.Helper()
+	client := fake.NewClientsetWithSelectors(objects...)
+	c.MessageClient = client
+	c.queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	t.Cleanup(c.queue.ShutDown)
+
+	factory := informers.NewSharedInformerFactory(client, 0)
+	if err := c.setUp(factory); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		factory.Shutdown()
+	})
+	factory.Start(ctx.Done())
+	for informer, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			t.Fatalf("timed out waiting for %v cache to sync", informer)
+		}
+	}
+	return client
+}
+
+// appliedStatus returns the Message status last applied through client, if
+// any.
+func appliedStatus(t *testing.T, client *fake.Clientset) (*messagev1.MessageStatus, bool) {
+	t.Helper()
+	actions := client.Actions()
+	for i := len(actions) - 1; i >= 0; i-- {
+		patch, ok := actions[i].(clienttesting.PatchAction)
+		if !ok || !patch.Matches("patch", messagev1.MessageResourcePlural) || patch.GetSubresource() != "status" {
+			continue
+		}
+		var message messagev1.Message
+		if err := json.Unmarshal(patch.GetPatch(), &message); err != nil {
+			t.Fatal(err)
+		}
+		return &message.Status, true
+	}
+	return nil, false
+}
+
+func TestSyncMessage(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	later := metav1.NewTime(time.Now().Add(time.Hour))
+	newMessage := func(spec messagev1.MessageSpec, status messagev1.MessageStatus) *messagev1.Message {
+		return &messagev1.Message{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "team-a",
+				Name:              "disk-full",
+				UID:               "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e",
+				CreationTimestamp: created,
+			},
+			Spec:   spec,
+			Status: status,
+		}
+	}
+	newSubscription := func(name string, sinkType messagev1.SinkType) *messagev1.Subscription {
+		return &messagev1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "team-a",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created.Add(-time.Minute)),
+			},
+			Spec: messagev1.SubscriptionSpec{
+				Topic:     "disks",
+				Recipient: messagev1.Recipient{Sink: sinkType},
+			},
+		}
+	}
+
+	// sent returns a Broadcasted Message called name created at at, with
+	// the idempotency key of the dedup cases.
+	sent := func(name string, at time.Time) *messagev1.Message {
+		return &messagev1.Message{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "team-a",
+				Name:              name,
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(at),
+			},
+			Spec:   messagev1.MessageSpec{IdempotencyKey: "disk-full-var"},
+			Status: messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		message *messagev1.Message
+		objects []runtime.Object
+		sinkErr error
+
+		wantErr         bool
+		wantRequeue     bool
+		wantState       messagev1.MessageState
+		wantDeliveries  map[string]messagev1.DeliveryState
+		wantSinkCalls   int
+		wantStatusWrite bool
+		wantCondition   string
+		wantSubscribers int32
+		wantDuplicateOf string
+		wantDeadLetters int
+	}{
+		{
+			name:            "new message",
+			message:         newMessage(messagev1.MessageSpec{Context: "disk /var is 95% full"}, messagev1.MessageStatus{}),
+			wantState:       messagev1.MessageStateBroadcasted,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateDelivered},
+			wantSinkCalls:   1,
+			wantStatusWrite: true,
+		},
+		{
+			name: "already broadcasted",
+			message: newMessage(messagev1.MessageSpec{}, messagev1.MessageStatus{
+				State:      messagev1.MessageStateBroadcasted,
+				Deliveries: []messagev1.MessageDelivery{{Sink: "log", State: messagev1.DeliveryStateDelivered, DeliveredAt: &created}},
+			}),
+			wantState:      messagev1.MessageStateBroadcasted,
+			wantDeliveries: map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateDelivered},
+		},
+		{
+			name:            "failing sink",
+			message:         newMessage(messagev1.MessageSpec{}, messagev1.MessageStatus{}),
+			sinkErr:         errors.New("connection refused"),
+			wantErr:         true,
+			wantState:       messagev1.MessageStateCreated,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateFailed},
+			wantSinkCalls:   1,
+			wantStatusWrite: true,
+		},
+		{
+			name:            "sink rejects the message",
+			message:         newMessage(messagev1.MessageSpec{}, messagev1.MessageStatus{}),
+			sinkErr:         &sink.PermanentError{Err: errors.New("400 Bad Request")},
+			wantState:       messagev1.MessageStateFailed,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateFailed},
+			wantSinkCalls:   1,
+			wantStatusWrite: true,
+			wantDeadLetters: 1,
+		},
+		{
+			name:            "deliver later",
+			message:         newMessage(messagev1.MessageSpec{DeliverAt: &later}, messagev1.MessageStatus{}),
+			wantRequeue:     true,
+			wantState:       messagev1.MessageStateScheduled,
+			wantStatusWrite: true,
+		},
+		{
+			name:            "missing channel",
+			message:         newMessage(messagev1.MessageSpec{ChannelRef: &messagev1.ChannelReference{Name: "pager"}}, messagev1.MessageStatus{}),
+			wantState:       messagev1.MessageStateCreated,
+			wantStatusWrite: true,
+			wantCondition:   messagev1.MessageConditionChannelResolved,
+		},
+		{
+			name:            "subscription",
+			message:         newMessage(messagev1.MessageSpec{Topic: "disks"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{newSubscription("oncall", messagev1.SinkTypeLog)},
+			wantState:       messagev1.MessageStateBroadcasted,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"subscription/team-a/oncall": messagev1.DeliveryStateDelivered},
+			wantSubscribers: 1,
+			wantStatusWrite: true,
+		},
+		{
+			name:            "subscription sink fails to build",
+			message:         newMessage(messagev1.MessageSpec{Topic: "disks"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{newSubscription("oncall", messagev1.SinkTypeWebhook)},
+			wantErr:         true,
+			wantState:       messagev1.MessageStateCreated,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"subscription/team-a/oncall": messagev1.DeliveryStateFailed},
+			wantSubscribers: 1,
+			wantStatusWrite: true,
+		},
+		{
+			name:    "one of the subscription sinks fails to build",
+			message: newMessage(messagev1.MessageSpec{Topic: "disks"}, messagev1.MessageStatus{}),
+			objects: []runtime.Object{
+				newSubscription("oncall", messagev1.SinkTypeLog),
+				newSubscription("managers", messagev1.SinkTypeWebhook),
+			},
+			wantErr:   true,
+			wantState: messagev1.MessageStateCreated,
+			wantDeliveries: map[string]messagev1.DeliveryState{
+				"subscription/team-a/oncall":   messagev1.DeliveryStateDelivered,
+				"subscription/team-a/managers": messagev1.DeliveryStateFailed,
+			},
+			wantSubscribers: 2,
+			wantStatusWrite: true,
+		},
+		{
+			name:            "duplicate in the dedup window",
+			message:         newMessage(messagev1.MessageSpec{IdempotencyKey: "disk-full-var"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{sent("disk-full-retry", created.Add(-time.Minute))},
+			wantState:       messagev1.MessageStateSuppressed,
+			wantDuplicateOf: "disk-full-retry",
+			wantStatusWrite: true,
+		},
+		{
+			name:            "duplicate after the dedup window",
+			message:         newMessage(messagev1.MessageSpec{IdempotencyKey: "disk-full-var"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{sent("disk-full-retry", created.Add(-time.Hour))},
+			wantState:       messagev1.MessageStateBroadcasted,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateDelivered},
+			wantSinkCalls:   1,
+			wantStatusWrite: true,
+		},
+		{
+			// Of two Messages created in the same second, the one with the
+			// lower name is the original.
+			name:            "duplicate created at the same time, lower name",
+			message:         newMessage(messagev1.MessageSpec{IdempotencyKey: "disk-full-var"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{sent("disk-empty", created.Time)},
+			wantState:       messagev1.MessageStateSuppressed,
+			wantDuplicateOf: "disk-empty",
+			wantStatusWrite: true,
+		},
+		{
+			name:            "duplicate created at the same time, higher name",
+			message:         newMessage(messagev1.MessageSpec{IdempotencyKey: "disk-full-var"}, messagev1.MessageStatus{}),
+			objects:         []runtime.Object{sent("disk-full-retry", created.Time)},
+			wantState:       messagev1.MessageStateBroadcasted,
+			wantDeliveries:  map[string]messagev1.DeliveryState{"log": messagev1.DeliveryStateDelivered},
+			wantSinkCalls:   1,
+			wantStatusWrite: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &testSink{name: "log", err: test.sinkErr}
+			c := &MessageController{Sinks: []sink.Sink{s}, DedupWindow: 10 * time.Minute}
+			client := startController(t, c, append(test.objects, test.message)...)
+			client.ClearActions()
+
+			ctx := context.Background()
+			requeueAfter, err := c.syncMessage(ctx, "team-a/disk-full")
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want one: %v", err, test.wantErr)
+			}
+			if (requeueAfter > 0) != test.wantRequeue {
+				t.Errorf("got requeue after %v, want one: %v", requeueAfter, test.wantRequeue)
+			}
+			if got := len(s.deliveries); got != test.wantSinkCalls {
+				t.Errorf("got %d deliveries to the sink, want %d", got, test.wantSinkCalls)
+			}
+			status, written := appliedStatus(t, client)
+			if written != test.wantStatusWrite {
+				t.Errorf("got status written: %v, want %v", written, test.wantStatusWrite)
+			}
+			if !written {
+				status = &test.message.Status
+			}
+			if status.State != test.wantState {
+				t.Errorf("got state %q, want %q", status.State, test.wantState)
+			}
+			deliveries := map[string]messagev1.DeliveryState{}
+			for _, d := range status.Deliveries {
+				deliveries[d.Sink] = d.State
+			}
+			if len(deliveries) != len(test.wantDeliveries) {
+				t.Errorf("got deliveries %v, want %v", deliveries, test.wantDeliveries)
+			}
+			for sinkName, state := range test.wantDeliveries {
+				if deliveries[sinkName] != state {
+					t.Errorf("got deliveries %v, want %v", deliveries, test.wantDeliveries)
+				}
+			}
+			duplicateOf := ""
+			if status.DuplicateOf != nil {
+				duplicateOf = status.DuplicateOf.Name
+			}
+			if duplicateOf != test.wantDuplicateOf {
+				t.Errorf("got duplicate of %q, want %q", duplicateOf, test.wantDuplicateOf)
+			}
+			if status.Subscribers != test.wantSubscribers {
+				t.Errorf("got %d subscribers, want %d", status.Subscribers, test.wantSubscribers)
+			}
+			if test.wantCondition != "" && !meta.IsStatusConditionFalse(status.Conditions, test.wantCondition) {
+				t.Errorf("got conditions %+v, want %s False", status.Conditions, test.wantCondition)
+			}
+
+			deadLetters, err := client.MessageV1().DeadLetters("team-a").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(deadLetters.Items) != test.wantDeadLetters {
+				t.Errorf("got %d DeadLetters, want %d", len(deadLetters.Items), test.wantDeadLetters)
+			}
+		})
+	}
+}
+
+// lockedSink is a testSink that can be delivered to from the workers of a
+// running controller.
+type lockedSink struct {
+	lock sync.Mutex
+	testSink
+}
+
+func (s *lockedSink) Deliver(ctx context.Context, delivery *sink.Delivery) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.testSink.Deliver(ctx, delivery)
+}
+
+func (s *lockedSink) delivered() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.deliveries)
+}
+
+// TestRun runs the controller against a fake clientset, which streams the
+// Messages created through it to the informers of the controller.
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewClientsetWithSelectors()
+	s := &lockedSink{testSink: testSink{name: "log"}}
+	c := &MessageController{
+		MessageClient: client,
+		Sinks:         []sink.Sink{s},
+	}
+	done := make(chan error)
+	go func() {
+		done <- c.Run(ctx)
+	}()
+
+	messages := client.MessageV1().Messages("team-a")
+	_, err := messages.Create(ctx, &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "disk-full"},
+		Spec:       messagev1.MessageSpec{Context: "disk /var is 95% full"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var message *messagev1.Message
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 10*time.Second, true, func(ctx context.Context) (bool, error) {
+		message, err = messages.Get(ctx, "disk-full", metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return message.Status.State == messagev1.MessageStateBroadcasted, nil
+	})
+	if err != nil {
+		t.Fatalf("Message was not broadcasted: %v, status %+v", err, message.Status)
+	}
+	if n := s.delivered(); n != 1 {
+		t.Errorf("got %d deliveries, want 1", n)
+	}
+	if d := findDelivery(&message.Status, "log"); d == nil || d.State != messagev1.DeliveryStateDelivered {
+		t.Errorf("got delivery %+v, want it Delivered", d)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestAnnounce(t *testing.T) {
+	tests := []struct {
+		name       string
+		sink       sink.Sink
+		wantEvents []string
+	}{
+		{name: "event sink", sink: &eventSink{testSink{name: "events"}}, wantEvents: []string{sink.EventCreated, sink.EventBroadcasted}},
+		{name: "other sink", sink: &testSink{name: "log"}, wantEvents: []string{sink.EventBroadcasted}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &MessageController{Sinks: []sink.Sink{test.sink}}
+			startController(t, c, &messagev1.Message{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "disk-full"},
+			})
+			if _, err := c.syncMessage(context.Background(), "team-a/disk-full"); err != nil {
+				t.Fatal(err)
+			}
+
+			var deliveries []*sink.Delivery
+			switch s := test.sink.(type) {
+			case *eventSink:
+				deliveries = s.deliveries
+			case *testSink:
+				deliveries = s.deliveries
+			}
+			var events []string
+			for _, delivery := range deliveries {
+				events = append(events, delivery.Event)
+			}
+			if len(events) != len(test.wantEvents) {
+				t.Fatalf("got events %q, want %q", events, test.wantEvents)
+			}
+			for i := range events {
+				if events[i] != test.wantEvents[i] {
+					t.Fatalf("got events %q, want %q", events, test.wantEvents)
+				}
+			}
+		})
+	}
+}
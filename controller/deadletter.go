@@ -0,0 +1,111 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// recordFailedAttempt appends a failed attempt to deliver to the sink
+// called sinkName to status, keeping only the latest ones and counting the
+// ones it drops.
+func recordFailedAttempt(status *messagev1.MessageStatus, sinkName string, err error) {
+	status.FailedAttempts = append(status.FailedAttempts, messagev1.DeliveryAttempt{
+		Sink:  sinkName,
+		Error: err.Error(),
+		Time:  metav1.Now(),
+	})
+	if extra := len(status.FailedAttempts) - messagev1.MaxFailedAttempts; extra > 0 {
+		status.FailedAttempts = status.FailedAttempts[extra:]
+		status.DroppedAttempts += int32(extra)
+	}
+}
+
+// exhausted tells whether a sink of status ran out of delivery attempts.
+func (c *MessageController) exhausted(status *messagev1.MessageStatus) bool {
+	if c.MaxAttempts <= 0 {
+		return false
+	}
+	for _, delivery := range status.Deliveries {
+		if delivery.State == messagev1.DeliveryStateFailed && int(delivery.Attempts) >= c.MaxAttempts {
+			return true
+		}
+	}
+	return false
+}
+
+// DeadLetterName returns the name of the DeadLetter of message. It is
+// derived from the UID of message, so a Message is dead-lettered at most
+// once even if marking it Failed has to be retried. The name of message is
+// shortened as needed for it to be a valid object name.
+func DeadLetterName(message *messagev1.Message) string {
+	uid := string(message.ObjectMeta.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	name := message.ObjectMeta.Name
+	if max := validation.DNS1123SubdomainMaxLength - len(uid) - 1; len(name) > max {
+		name = strings.TrimRight(name[:max], ".-")
+	}
+	return name + "-" + uid
+}
+
+// deadLetter gives up on message: it copies it into a DeadLetter and marks
+// it Failed.
+func (c *MessageController) deadLetter(ctx context.Context, message *messagev1.Message) error {
+	namespace := c.DeadLetterNamespace
+	if namespace == "" {
+		namespace = message.ObjectMeta.Namespace
+	}
+
+	deadLetter := &messagev1.DeadLetter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeadLetterName(message),
+			Namespace: namespace,
+		},
+		Spec: messagev1.DeadLetterSpec{
+			Message: messagev1.DeadLetterMessage{
+				Namespace:         message.ObjectMeta.Namespace,
+				Name:              message.ObjectMeta.Name,
+				UID:               message.ObjectMeta.UID,
+				Labels:            message.ObjectMeta.Labels,
+				CreationTimestamp: message.ObjectMeta.CreationTimestamp,
+				Spec:              message.Spec,
+			},
+			Attempts:        message.Status.FailedAttempts,
+			DroppedAttempts: message.Status.DroppedAttempts,
+			FailedAt:        metav1.Now(),
+		},
+	}
+	_, err := c.MessageClient.MessageV1().DeadLetters(namespace).Create(ctx, deadLetter, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating DeadLetter: %v", err)
+	}
+
+	fmt.Printf("[CONTROLLER] Gave up on Message %s/%s, dead-lettered as %s/%s\n",
+		message.ObjectMeta.Namespace, message.ObjectMeta.Name, namespace, deadLetter.ObjectMeta.Name)
+	message.Status.State = messagev1.MessageStateFailed
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/sink"
+)
+
+func TestDeadLetterName(t *testing.T) {
+	tests := []struct {
+		name string
+		uid  string
+		want string
+	}{
+		{name: "disk-full", uid: "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e", want: "disk-full-6a1c0b5e"},
+		{name: "disk-full", uid: "6a1c", want: "disk-full-6a1c"},
+		{name: strings.Repeat("a", 253), uid: "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e", want: strings.Repeat("a", 244) + "-6a1c0b5e"},
+		{name: strings.Repeat("a", 243) + ".b", uid: "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e", want: strings.Repeat("a", 243) + "-6a1c0b5e"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got := DeadLetterName(&messagev1.Message{ObjectMeta: metav1.ObjectMeta{Name: test.name, UID: types.UID(test.uid)}})
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) != 0 {
+				t.Errorf("invalid name %q: %v", got, errs)
+			}
+		})
+	}
+}
+
+func TestRecordFailedAttempt(t *testing.T) {
+	var status messagev1.MessageStatus
+	for i := 0; i < messagev1.MaxFailedAttempts+5; i++ {
+		recordFailedAttempt(&status, "log", fmt.Errorf("attempt %d", i))
+	}
+	if len(status.FailedAttempts) != messagev1.MaxFailedAttempts {
+		t.Fatalf("got %d failed attempts, want %d", len(status.FailedAttempts), messagev1.MaxFailedAttempts)
+	}
+	if status.DroppedAttempts != 5 {
+		t.Errorf("got %d dropped attempts, want 5", status.DroppedAttempts)
+	}
+	if status.FailedAttempts[0].Error != "attempt 5" {
+		t.Errorf("got oldest attempt %q, want %q", status.FailedAttempts[0].Error, "attempt 5")
+	}
+}
+
+func TestDeadLetter(t *testing.T) {
+	tests := []struct {
+		name           string
+		attempts       int32
+		wantDeadLetter bool
+	}{
+		{name: "attempts left", attempts: 1},
+		{name: "last attempt", attempts: 29, wantDeadLetter: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := messagev1.MessageStatus{
+				State:      messagev1.MessageStateCreated,
+				Deliveries: []messagev1.MessageDelivery{{Sink: "log", State: messagev1.DeliveryStateFailed, Attempts: test.attempts}},
+			}
+			for i := int32(0); i < test.attempts; i++ {
+				recordFailedAttempt(&status, "log", errors.New("connection refused"))
+			}
+			message := &messagev1.Message{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "team-a",
+					Name:              "disk-full",
+					UID:               "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e",
+					Labels:            map[string]string{"team": "a"},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+				Spec:   messagev1.MessageSpec{Context: "disk /var is 95% full"},
+				Status: status,
+			}
+			c := &MessageController{
+				Sinks:               []sink.Sink{&testSink{name: "log", err: errors.New("connection refused")}},
+				MaxAttempts:         30,
+				DeadLetterNamespace: "dead-letters",
+			}
+			client := startController(t, c, message)
+
+			ctx := context.Background()
+			if _, err := c.syncMessage(ctx, "team-a/disk-full"); (err == nil) != test.wantDeadLetter {
+				t.Errorf("got error %v, want one: %v", err, !test.wantDeadLetter)
+			}
+
+			deadLetters, err := client.MessageV1().DeadLetters("dead-letters").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			applied, _ := appliedStatus(t, client)
+			if !test.wantDeadLetter {
+				if len(deadLetters.Items) != 0 {
+					t.Errorf("got %d DeadLetters, want none", len(deadLetters.Items))
+				}
+				if applied.State != messagev1.MessageStateCreated {
+					t.Errorf("got state %q, want %q", applied.State, messagev1.MessageStateCreated)
+				}
+				return
+			}
+
+			if len(deadLetters.Items) != 1 {
+				t.Fatalf("got %d DeadLetters, want 1", len(deadLetters.Items))
+			}
+			deadLetter := deadLetters.Items[0]
+			if deadLetter.ObjectMeta.Name != "disk-full-6a1c0b5e" {
+				t.Errorf("got DeadLetter %s, want disk-full-6a1c0b5e", deadLetter.ObjectMeta.Name)
+			}
+			if got := deadLetter.Spec.Message; got.Namespace != "team-a" || got.Name != "disk-full" || got.UID != message.ObjectMeta.UID ||
+				got.Labels["team"] != "a" || got.Spec.Context != message.Spec.Context {
+				t.Errorf("got DeadLetter of %+v, want the Message", got)
+			}
+			if len(deadLetter.Spec.Attempts) != messagev1.MaxFailedAttempts || deadLetter.Spec.DroppedAttempts != 30-messagev1.MaxFailedAttempts {
+				t.Errorf("got %d attempts and %d dropped, want %d and %d", len(deadLetter.Spec.Attempts), deadLetter.Spec.DroppedAttempts,
+					messagev1.MaxFailedAttempts, 30-messagev1.MaxFailedAttempts)
+			}
+			if applied.State != messagev1.MessageStateFailed {
+				t.Errorf("got state %q, want %q", applied.State, messagev1.MessageStateFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// idempotencyIndex indexes Messages by "<namespace>/<idempotency key>".
+const idempotencyIndex = "idempotencyKey"
+
+func indexByIdempotencyKey(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.IdempotencyKey == "" {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + message.Spec.IdempotencyKey}, nil
+}
+
+// dedupWindow returns how long after a Message with the idempotency key of
+// message, message is suppressed as its duplicate.
+func (c *MessageController) dedupWindow(message *messagev1.Message) time.Duration {
+	if ref := message.Spec.ChannelRef; ref != nil {
+		channel, err := c.channelLister.Get(ref.Name)
+		if err == nil && channel.Spec.DedupWindow != nil {
+			return channel.Spec.DedupWindow.Duration
+		}
+	}
+	return c.DedupWindow
+}
+
+// duplicated returns the Message that message duplicates, or nil if
+// message is to be broadcast. That is the earliest Message with the same
+// idempotency key and channel created at most the dedup window before
+// message, and neither suppressed nor failed itself, so that retrying a
+// failed Message is not suppressed. Messages are ordered by creation
+// time, then name, so that of two Messages created at once, the same one
+// is suppressed whichever is reconciled first. Messages that started being
+// delivered are never suppressed.
+func (c *MessageController) duplicated(message *messagev1.Message) *messagev1.Message {
+	if message.Spec.IdempotencyKey == "" || len(message.Status.Deliveries) != 0 ||
+		message.Status.State == messagev1.MessageStateBroadcasted {
+		return nil
+	}
+	window := c.dedupWindow(message)
+	if window <= 0 {
+		return nil
+	}
+
+	objs, err := c.messageIndex.ByIndex(idempotencyIndex, message.ObjectMeta.Namespace+"/"+message.Spec.IdempotencyKey)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages with idempotency key %s: %v\n", message.Spec.IdempotencyKey, err)
+		return nil
+	}
+
+	created := message.ObjectMeta.CreationTimestamp.Time
+	var original *messagev1.Message
+	for _, obj := range objs {
+		other := obj.(*messagev1.Message)
+		if other.ObjectMeta.UID == message.ObjectMeta.UID ||
+			other.Status.State == messagev1.MessageStateSuppressed ||
+			other.Status.State == messagev1.MessageStateFailed ||
+			channelName(other) != channelName(message) ||
+			!createdBefore(other, message) ||
+			created.Sub(other.ObjectMeta.CreationTimestamp.Time) > window {
+			continue
+		}
+		if original == nil || createdBefore(other, original) {
+			original = other
+		}
+	}
+	return original
+}
+
+func channelName(message *messagev1.Message) string {
+	if message.Spec.ChannelRef == nil {
+		return ""
+	}
+	return message.Spec.ChannelRef.Name
+}
+
+func createdBefore(a, b *messagev1.Message) bool {
+	at, bt := a.ObjectMeta.CreationTimestamp.Time, b.ObjectMeta.CreationTimestamp.Time
+	if !at.Equal(bt) {
+		return at.Before(bt)
+	}
+	return a.ObjectMeta.Name < b.ObjectMeta.Name
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+	"github.com/yasker/example-crd/sink"
+)
+
+// escalationPolicyIndex indexes Messages by "<namespace>/<name>" of the
+// EscalationPolicy they reference.
+const escalationPolicyIndex = "escalationPolicy"
+
+func indexByEscalationPolicy(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.EscalationPolicyRef == nil {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + message.Spec.EscalationPolicyRef.Name}, nil
+}
+
+func (c *MessageController) watchEscalationPolicies(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().EscalationPolicies()
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onEscalationPolicyChange,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.onEscalationPolicyChange(newObj)
+		},
+		DeleteFunc: c.onEscalationPolicyChange,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.escalationPolicyLister = informer.Lister()
+	return nil
+}
+
+func (c *MessageController) onEscalationPolicyChange(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		fmt.Printf("ERROR getting key for %#v: %v\n", obj, err)
+		return
+	}
+	messages, err := c.messageIndex.ByIndex(escalationPolicyIndex, key)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages of EscalationPolicy %s: %v\n", key, err)
+		return
+	}
+	for _, message := range messages {
+		c.enqueue(message)
+	}
+}
+
+// escalationPolicy returns the EscalationPolicy message references,
+// recording whether it exists in its EscalationPolicyResolved condition.
+func (c *MessageController) escalationPolicy(message *messagev1.Message) (*messagev1.EscalationPolicy, bool) {
+	name := message.Spec.EscalationPolicyRef.Name
+	policy, err := c.escalationPolicyLister.EscalationPolicies(message.ObjectMeta.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		setCondition(message, messagev1.MessageConditionEscalationPolicyResolved, metav1.ConditionFalse,
+			messagev1.MessageReasonEscalationPolicyNotFound, fmt.Sprintf("EscalationPolicy %s does not exist", name))
+		return nil, false
+	}
+	if err != nil {
+		fmt.Printf("ERROR getting EscalationPolicy %s/%s: %v\n", message.ObjectMeta.Namespace, name, err)
+		return nil, false
+	}
+
+	setCondition(message, messagev1.MessageConditionEscalationPolicyResolved, metav1.ConditionTrue,
+		messagev1.MessageReasonEscalationPolicyResolved, fmt.Sprintf("Escalating with EscalationPolicy %s", name))
+	return policy, true
+}
+
+// escalateUndelivered starts or continues the escalation of a message that
+// failed to be delivered with deliveryErr, if its EscalationPolicy escalates
+// undelivered Messages and it is overdue.
+func (c *MessageController) escalateUndelivered(ctx context.Context, message *messagev1.Message, deliveryErr error) (time.Duration, error) {
+	if message.Spec.EscalationPolicyRef == nil {
+		return 0, deliveryErr
+	}
+	policy, ok := c.escalationPolicy(message)
+	if !ok || policy.Spec.UndeliveredAfter == nil {
+		return 0, deliveryErr
+	}
+	if message.Status.Escalation == nil {
+		overdueAt := message.ObjectMeta.CreationTimestamp.Add(policy.Spec.UndeliveredAfter.Duration)
+		if remaining := time.Until(overdueAt); remaining > 0 {
+			return remaining, deliveryErr
+		}
+	}
+
+	requeueAfter, err := c.runEscalation(ctx, message, policy, messagev1.EscalationReasonUndelivered)
+	if err != nil {
+		return 0, utilerrors.NewAggregate([]error{deliveryErr, err})
+	}
+	return requeueAfter, deliveryErr
+}
+
+// escalateUnacknowledged starts or continues the escalation of a message
+// that was not acknowledged in time.
+func (c *MessageController) escalateUnacknowledged(ctx context.Context, message *messagev1.Message) (time.Duration, error) {
+	policy, ok := c.escalationPolicy(message)
+	if !ok {
+		return 0, nil
+	}
+	return c.runEscalation(ctx, message, policy, messagev1.EscalationReasonUnacknowledged)
+}
+
+// runEscalation runs the steps of policy that are due for message, starting
+// the escalation for reason if it has not started yet. The position in the
+// policy is kept in the message status, so that the escalation resumes where
+// it was after a restart. A positive duration is how long until the next
+// step is due.
+func (c *MessageController) runEscalation(ctx context.Context, message *messagev1.Message, policy *messagev1.EscalationPolicy, reason messagev1.EscalationReason) (time.Duration, error) {
+	steps := policy.Spec.Steps
+	escalation := message.Status.Escalation
+	if escalation == nil {
+		escalation = &messagev1.EscalationStatus{
+			Policy: policy.ObjectMeta.Name,
+			Reason: reason,
+			State:  messagev1.EscalationStateActive,
+		}
+		if len(steps) != 0 {
+			nextAt := metav1.NewTime(time.Now().Add(steps[0].Delay.Duration))
+			escalation.NextAt = &nextAt
+		}
+		message.Status.Escalation = escalation
+	}
+	if escalation.State != messagev1.EscalationStateActive {
+		return 0, nil
+	}
+
+	for int(escalation.Step) < len(steps) {
+		step := steps[escalation.Step]
+		if escalation.NextAt != nil {
+			if remaining := time.Until(escalation.NextAt.Time); remaining > 0 {
+				return remaining, nil
+			}
+		}
+
+		r, _, err := c.channelRoute(step.ChannelRef.Name, message.ObjectMeta.Namespace)
+		if err != nil {
+			return 0, fmt.Errorf("escalation step %d: %v", escalation.Step, err)
+		}
+		stage := fmt.Sprintf("escalation-%d-%d", escalation.Step, escalation.Repeats)
+		retryAfter, err := c.escalate(ctx, message, r, stage)
+		if err != nil {
+			return 0, fmt.Errorf("escalation step %d: %v", escalation.Step, err)
+		}
+		if retryAfter > 0 {
+			return retryAfter, nil
+		}
+
+		escalatedAt := metav1.Now()
+		escalation.LastEscalatedAt = &escalatedAt
+		escalation.Repeats++
+		delay := step.Delay.Duration
+		if escalation.Repeats > step.Repeat {
+			escalation.Step++
+			escalation.Repeats = 0
+			if int(escalation.Step) < len(steps) {
+				delay = steps[escalation.Step].Delay.Duration
+			}
+		}
+		nextAt := metav1.NewTime(escalatedAt.Add(delay))
+		escalation.NextAt = &nextAt
+	}
+
+	escalation.State = messagev1.EscalationStateCompleted
+	escalation.NextAt = nil
+	return 0, nil
+}
+
+// resolveEscalation stops an active escalation of message started for
+// reason, now that the reason is gone.
+func resolveEscalation(message *messagev1.Message, reason messagev1.EscalationReason) {
+	escalation := message.Status.Escalation
+	if escalation == nil || escalation.Reason != reason || escalation.State != messagev1.EscalationStateActive {
+		return
+	}
+	escalation.State = messagev1.EscalationStateResolved
+	escalation.NextAt = nil
+}
+
+// escalate broadcasts message to the sinks of r, as the delivery stage. If
+// the circuit breaker of one of them is open or a rate limit postpones the
+// delivery to it, it stops there and returns how long to wait, so that the
+// step is retried then.
+func (c *MessageController) escalate(ctx context.Context, message *messagev1.Message, r route, stage string) (time.Duration, error) {
+	fmt.Printf("[CONTROLLER] Escalating Message %s/%s\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name)
+	key := message.ObjectMeta.Namespace + "/" + message.ObjectMeta.Name
+	var errs []error
+	for _, s := range r.sinks {
+		if delay, ok := c.breakers.allow(s.Name(), key); !ok {
+			fmt.Printf("[CONTROLLER] Parked escalation of %s until the circuit breaker of sink %s closes\n", key, s.Name())
+			return delay, nil
+		}
+		if delay, scope := c.reserve(message, r, s.Name()); delay > 0 {
+			c.breakers.release(s.Name())
+			fmt.Printf("[CONTROLLER] Rate limited escalation of %s by the %s rate limit, retrying in %v\n", key, scope, delay)
+			setThrottled(message, scope, delay)
+			return delay, nil
+		}
+		clearThrottled(message)
+		err := s.Deliver(ctx, sink.NewDelivery(message, s.Name(), stage))
+		c.recordDelivery(s.Name(), err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("escalating to sink %s: %v", s.Name(), err))
+		}
+	}
+	return 0, utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// orderingIndex indexes Messages by "<namespace>/<ordering key>". Recurring
+// Messages are left out, they are never broadcast themselves and would
+// block their group forever.
+const orderingIndex = "orderingKey"
+
+func indexByOrderingKey(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.OrderingKey == "" || message.Spec.Schedule != nil {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + message.Spec.OrderingKey}, nil
+}
+
+// done tells whether message no longer holds back the Messages after it in
+// its ordering group. A Message that failed does not block its group.
+func done(message *messagev1.Message) bool {
+	switch message.Status.State {
+	case messagev1.MessageStateBroadcasted, messagev1.MessageStateSuppressed, messagev1.MessageStateFailed:
+		return true
+	}
+	return false
+}
+
+// enqueueSuccessors requeues the ordering group of a Message that just got
+// done, so that the next one in line is delivered.
+func (c *MessageController) enqueueSuccessors(oldMessage, newMessage *messagev1.Message) {
+	if newMessage.Spec.OrderingKey == "" || done(oldMessage) || !done(newMessage) {
+		return
+	}
+	messages, err := c.messageIndex.ByIndex(orderingIndex, newMessage.ObjectMeta.Namespace+"/"+newMessage.Spec.OrderingKey)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages with ordering key %s: %v\n", newMessage.Spec.OrderingKey, err)
+		return
+	}
+	for _, message := range messages {
+		c.enqueue(message)
+	}
+}
+
+// blocked returns false if message may be delivered now, recording in its
+// Blocked condition whether it waits for an earlier Message with the same
+// ordering key. Only the first Message of a group that is not done is ever
+// unblocked, so the group is delivered one Message at a time in creation
+// order, while the workers deliver other groups in parallel.
+func (c *MessageController) blocked(message *messagev1.Message) bool {
+	if message.Spec.OrderingKey == "" || done(message) {
+		return false
+	}
+
+	objs, err := c.messageIndex.ByIndex(orderingIndex, message.ObjectMeta.Namespace+"/"+message.Spec.OrderingKey)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages with ordering key %s: %v\n", message.Spec.OrderingKey, err)
+		return true
+	}
+	var head *messagev1.Message
+	for _, obj := range objs {
+		other := obj.(*messagev1.Message)
+		if other.ObjectMeta.UID == message.ObjectMeta.UID || done(other) || !createdBefore(other, message) {
+			continue
+		}
+		if head == nil || createdBefore(other, head) {
+			head = other
+		}
+	}
+
+	if head != nil {
+		setCondition(message, messagev1.MessageConditionBlocked, metav1.ConditionTrue,
+			messagev1.MessageReasonWaitingForPredecessor, fmt.Sprintf("Waiting for Message %s to be broadcasted", head.ObjectMeta.Name))
+		return true
+	}
+	if meta.FindStatusCondition(message.Status.Conditions, messagev1.MessageConditionBlocked) != nil {
+		setCondition(message, messagev1.MessageConditionBlocked, metav1.ConditionFalse,
+			messagev1.MessageReasonPredecessorsDone, "Every earlier Message with the same ordering key was broadcasted")
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/sink"
+)
+
+// orderedMessage returns a Message called name of the ordering group
+// disk-var, created at created.
+func orderedMessage(name string, created time.Time, state messagev1.MessageState) *messagev1.Message {
+	return &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "team-a",
+			Name:              name,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec:   messagev1.MessageSpec{OrderingKey: "disk-var"},
+		Status: messagev1.MessageStatus{State: state},
+	}
+}
+
+// waitForKey takes keys off the queue of c until it gets key.
+func waitForKey(t *testing.T, c *MessageController, key string) {
+	t.Helper()
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		for c.queue.Len() > 0 {
+			got, _ := c.queue.Get()
+			c.queue.Done(got)
+			if got == key {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("waiting for %s to be queued: %v", key, err)
+	}
+}
+
+func TestOrdering(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-time.Minute).Truncate(time.Second)
+	s := &testSink{name: "log"}
+	c := &MessageController{Sinks: []sink.Sink{s}}
+	client := startController(t, c,
+		orderedMessage("disk-full", created, ""),
+		orderedMessage("disk-fuller", created.Add(time.Second), ""),
+	)
+	waitForKey(t, c, "team-a/disk-full")
+	waitForKey(t, c, "team-a/disk-fuller")
+
+	// The later Message waits for the earlier one.
+	if _, err := c.syncMessage(ctx, "team-a/disk-fuller"); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.deliveries) != 0 {
+		t.Fatalf("got %d deliveries, want the later Message held back", len(s.deliveries))
+	}
+	status, _ := appliedStatus(t, client)
+	if status.State != messagev1.MessageStateCreated || !meta.IsStatusConditionTrue(status.Conditions, messagev1.MessageConditionBlocked) {
+		t.Fatalf("got status %+v, want it Created and Blocked", status)
+	}
+	waitForKey(t, c, "team-a/disk-fuller")
+
+	// Broadcasting the earlier Message queues the later one.
+	if _, err := c.syncMessage(ctx, "team-a/disk-full"); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.deliveries) != 1 || s.deliveries[0].Message.ObjectMeta.Name != "disk-full" {
+		t.Fatalf("got %d deliveries, want the earlier Message delivered", len(s.deliveries))
+	}
+	waitForKey(t, c, "team-a/disk-fuller")
+
+	if _, err := c.syncMessage(ctx, "team-a/disk-fuller"); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.deliveries) != 2 || s.deliveries[1].Message.ObjectMeta.Name != "disk-fuller" {
+		t.Fatalf("got %d deliveries, want the later Message delivered", len(s.deliveries))
+	}
+	status, _ = appliedStatus(t, client)
+	if status.State != messagev1.MessageStateBroadcasted || !meta.IsStatusConditionFalse(status.Conditions, messagev1.MessageConditionBlocked) {
+		t.Errorf("got status %+v, want it Broadcasted and no longer Blocked", status)
+	}
+}
+
+func TestEnqueueSuccessors(t *testing.T) {
+	created := time.Now().Add(-time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name        string
+		oldState    messagev1.MessageState
+		newState    messagev1.MessageState
+		orderingKey string
+		wantQueued  bool
+	}{
+		{name: "broadcasted", oldState: messagev1.MessageStateCreated, newState: messagev1.MessageStateBroadcasted, orderingKey: "disk-var", wantQueued: true},
+		{name: "failed", oldState: messagev1.MessageStateCreated, newState: messagev1.MessageStateFailed, orderingKey: "disk-var", wantQueued: true},
+		{name: "still pending", oldState: messagev1.MessageStateCreated, newState: messagev1.MessageStateCreated, orderingKey: "disk-var"},
+		{name: "already done", oldState: messagev1.MessageStateBroadcasted, newState: messagev1.MessageStateBroadcasted, orderingKey: "disk-var"},
+		{name: "no ordering key", oldState: messagev1.MessageStateCreated, newState: messagev1.MessageStateBroadcasted},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			successor := orderedMessage("disk-fuller", created.Add(time.Second), "")
+			c := &MessageController{}
+			startController(t, c, successor)
+			waitForKey(t, c, "team-a/disk-fuller")
+
+			oldMessage := orderedMessage("disk-full", created, test.oldState)
+			oldMessage.Spec.OrderingKey = test.orderingKey
+			newMessage := oldMessage.DeepCopy()
+			newMessage.Status.State = test.newState
+			c.enqueueSuccessors(oldMessage, newMessage)
+
+			if got := c.queue.Len() == 1; got != test.wantQueued {
+				t.Errorf("got successor queued: %v, want %v", got, test.wantQueued)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// maxScheduleCatchUp bounds how far back a recurring Message looks for a
+// missed run, e.g. after the controller was down or its status was lost.
+const maxScheduleCatchUp = 7 * 24 * time.Hour
+
+// ownerIndex indexes Messages created by a recurring Message by the
+// "<namespace>/<name>" key of the recurring Message.
+const ownerIndex = "owner"
+
+func indexByOwner(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok {
+		return nil, nil
+	}
+	owner := metav1.GetControllerOf(message)
+	if owner == nil || owner.Kind != "Message" || owner.APIVersion != messagev1.SchemeGroupVersion.String() {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + owner.Name}, nil
+}
+
+// schedule creates the Message a recurring message is due to create, if
+// any, and returns how long until the next one is due. Runs missed while
+// the controller was down are not caught up on, only the most recent one
+// is created, like a CronJob does.
+func (c *MessageController) schedule(ctx context.Context, message *messagev1.Message) (time.Duration, error) {
+	status := &message.Status
+	status.State = messagev1.MessageStateScheduled
+
+	sched, err := parseSchedule(message.Spec.Schedule)
+	if err != nil {
+		setCondition(message, messagev1.MessageConditionScheduleValid, metav1.ConditionFalse,
+			messagev1.MessageReasonScheduleInvalid, err.Error())
+		status.NextScheduleTime = nil
+		return 0, nil
+	}
+	setCondition(message, messagev1.MessageConditionScheduleValid, metav1.ConditionTrue,
+		messagev1.MessageReasonScheduleValid, fmt.Sprintf("Scheduled with %q", message.Spec.Schedule.Cron))
+
+	now := time.Now()
+	earliest := message.ObjectMeta.CreationTimestamp.Time
+	if status.LastScheduleTime != nil {
+		earliest = status.LastScheduleTime.Time
+	}
+	if catchUp := now.Add(-maxScheduleCatchUp); earliest.Before(catchUp) {
+		earliest = catchUp
+	}
+	var missed time.Time
+	for t := sched.Next(earliest); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		missed = t
+	}
+	if !missed.IsZero() {
+		if err := c.createScheduled(ctx, message, missed); err != nil {
+			return 0, err
+		}
+		lastScheduleTime := metav1.NewTime(missed)
+		status.LastScheduleTime = &lastScheduleTime
+	}
+
+	if err := c.pruneScheduled(ctx, message); err != nil {
+		fmt.Printf("ERROR pruning Messages of %s/%s: %v\n", message.ObjectMeta.Namespace, message.ObjectMeta.Name, err)
+	}
+
+	next := sched.Next(now)
+	if next.IsZero() {
+		status.NextScheduleTime = nil
+		return 0, nil
+	}
+	nextScheduleTime := metav1.NewTime(next)
+	status.NextScheduleTime = &nextScheduleTime
+	return time.Until(next), nil
+}
+
+func parseSchedule(schedule *messagev1.MessageSchedule) (cron.Schedule, error) {
+	spec := schedule.Cron
+	if strings.Contains(spec, "TZ=") {
+		return nil, fmt.Errorf("set the time zone of schedule %q with timeZone", spec)
+	}
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", schedule.TimeZone)
+		}
+		spec = "CRON_TZ=" + schedule.TimeZone + " " + spec
+	}
+	return cron.ParseStandard(spec)
+}
+
+// createScheduled creates the Message recurring message schedules at at.
+// Its name is derived from at, so the same run is never created twice.
+func (c *MessageController) createScheduled(ctx context.Context, message *messagev1.Message, at time.Time) error {
+	spec := message.Spec.DeepCopy()
+	spec.Schedule = nil
+	spec.DeliverAt = nil
+	// Every run is a new message, not a retry of the previous one.
+	spec.IdempotencyKey = ""
+
+	labels := map[string]string{}
+	for key, value := range message.ObjectMeta.Labels {
+		labels[key] = value
+	}
+	labels[messagev1.ScheduledByLabel] = message.ObjectMeta.Name
+
+	scheduled := &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", message.ObjectMeta.Name, at.Unix()/60),
+			Namespace: message.ObjectMeta.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				messagev1.ScheduledAtAnnotation: at.Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(message, messagev1.SchemeGroupVersion.WithKind("Message")),
+			},
+		},
+		Spec: *spec,
+	}
+	_, err := c.MessageClient.MessageV1().Messages(message.ObjectMeta.Namespace).Create(ctx, scheduled, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[CONTROLLER] Scheduled Message %s/%s for %v\n", scheduled.ObjectMeta.Namespace, scheduled.ObjectMeta.Name, at)
+	return nil
+}
+
+// pruneScheduled deletes the oldest broadcasted Messages created by
+// recurring message beyond its history limit. Messages still waiting to be
+// broadcast are never deleted.
+func (c *MessageController) pruneScheduled(ctx context.Context, message *messagev1.Message) error {
+	limit := int32(messagev1.DefaultScheduleHistoryLimit)
+	if message.Spec.Schedule.HistoryLimit != nil {
+		limit = *message.Spec.Schedule.HistoryLimit
+	}
+	// The schema rejects negative limits, but Messages stored before it
+	// did may still have one.
+	if limit < 0 {
+		limit = 0
+	}
+
+	objs, err := c.messageIndex.ByIndex(ownerIndex, message.ObjectMeta.Namespace+"/"+message.ObjectMeta.Name)
+	if err != nil {
+		return err
+	}
+	var finished []*messagev1.Message
+	for _, obj := range objs {
+		scheduled := obj.(*messagev1.Message)
+		if scheduled.Status.State == messagev1.MessageStateBroadcasted {
+			finished = append(finished, scheduled)
+		}
+	}
+	if int32(len(finished)) <= limit {
+		return nil
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].ObjectMeta.CreationTimestamp.Before(&finished[j].ObjectMeta.CreationTimestamp)
+	})
+	for _, scheduled := range finished[:int32(len(finished))-limit] {
+		err := c.MessageClient.MessageV1().Messages(scheduled.ObjectMeta.Namespace).Delete(ctx, scheduled.ObjectMeta.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(scheduled.ObjectMeta.UID)),
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
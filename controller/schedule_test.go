@@ -0,0 +1,179 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/sink"
+)
+
+// recurringMessage returns a Message sent every minute, created ago.
+func recurringMessage(ago time.Duration, historyLimit *int32) *messagev1.Message {
+	return &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "team-a",
+			Name:              "standup",
+			UID:               "0b6c2a1e-4d5f-4e6a-8b7c-9d0e1f2a3b4c",
+			Labels:            map[string]string{"team": "a"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-ago)),
+		},
+		Spec: messagev1.MessageSpec{
+			Context:  "standup in 5 minutes",
+			Schedule: &messagev1.MessageSchedule{Cron: "* * * * *", HistoryLimit: historyLimit},
+		},
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule messagev1.MessageSchedule
+		wantErr  bool
+	}{
+		{name: "cron", schedule: messagev1.MessageSchedule{Cron: "0 9 * * 1-5"}},
+		{name: "time zone", schedule: messagev1.MessageSchedule{Cron: "0 9 * * 1-5", TimeZone: "Europe/Berlin"}},
+		{name: "time zone in cron", schedule: messagev1.MessageSchedule{Cron: "CRON_TZ=Europe/Berlin 0 9 * * 1-5"}, wantErr: true},
+		{name: "unknown time zone", schedule: messagev1.MessageSchedule{Cron: "0 9 * * 1-5", TimeZone: "Mars/Olympus"}, wantErr: true},
+		{name: "invalid cron", schedule: messagev1.MessageSchedule{Cron: "every morning"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseSchedule(&test.schedule)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want one: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	ctx := context.Background()
+	c := &MessageController{Sinks: []sink.Sink{&testSink{name: "log"}}}
+	recurring := recurringMessage(10*time.Minute, nil)
+	client := startController(t, c, recurring)
+
+	for i := 0; i < 2; i++ {
+		requeueAfter, err := c.syncMessage(ctx, "team-a/standup")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requeueAfter <= 0 || requeueAfter > time.Minute {
+			t.Errorf("got requeue after %v, want it within the next minute", requeueAfter)
+		}
+	}
+
+	list, err := client.MessageV1().Messages("team-a").List(ctx, metav1.ListOptions{
+		LabelSelector: messagev1.ScheduledByLabel + "=standup",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The missed runs are not caught up on, only the last one is created,
+	// and only once.
+	if len(list.Items) != 1 {
+		t.Fatalf("got %d scheduled Messages, want 1", len(list.Items))
+	}
+	scheduled := list.Items[0]
+	if owner := metav1.GetControllerOf(&scheduled); owner == nil || owner.UID != recurring.ObjectMeta.UID {
+		t.Errorf("got owner %+v, want the recurring Message", owner)
+	}
+	if scheduled.ObjectMeta.Labels["team"] != "a" {
+		t.Errorf("got labels %v, want the labels of the recurring Message", scheduled.ObjectMeta.Labels)
+	}
+	if scheduled.Spec.Schedule != nil || scheduled.Spec.Context != recurring.Spec.Context {
+		t.Errorf("got spec %+v, want the spec of the recurring Message without its schedule", scheduled.Spec)
+	}
+	if _, err := time.Parse(time.RFC3339, scheduled.ObjectMeta.Annotations[messagev1.ScheduledAtAnnotation]); err != nil {
+		t.Errorf("scheduled-at annotation: %v", err)
+	}
+
+	status, _ := appliedStatus(t, client)
+	if status == nil || status.State != messagev1.MessageStateScheduled || status.LastScheduleTime == nil || status.NextScheduleTime == nil {
+		t.Errorf("got status %+v, want it Scheduled with the last and next schedule times", status)
+	}
+}
+
+func TestPruneScheduled(t *testing.T) {
+	tests := []struct {
+		name         string
+		historyLimit *int32
+		wantKept     int
+	}{
+		{name: "default", wantKept: messagev1.DefaultScheduleHistoryLimit},
+		{name: "one", historyLimit: ptr.To[int32](1), wantKept: 1},
+		{name: "zero", historyLimit: ptr.To[int32](0), wantKept: 0},
+		{name: "negative", historyLimit: ptr.To[int32](-1), wantKept: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			recurring := recurringMessage(time.Hour, test.historyLimit)
+			objects := []runtime.Object{recurring}
+			// Five broadcasted runs and one still pending, oldest first.
+			for i := 0; i < 6; i++ {
+				scheduled := &messagev1.Message{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:         "team-a",
+						Name:              fmt.Sprintf("standup-%d", i),
+						CreationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(i-6) * time.Minute)),
+						OwnerReferences: []metav1.OwnerReference{
+							*metav1.NewControllerRef(recurring, messagev1.SchemeGroupVersion.WithKind("Message")),
+						},
+					},
+					Status: messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted},
+				}
+				if i == 5 {
+					scheduled.Status.State = messagev1.MessageStateCreated
+				}
+				objects = append(objects, scheduled)
+			}
+			c := &MessageController{}
+			client := startController(t, c, objects...)
+
+			if err := c.pruneScheduled(ctx, recurring); err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := client.MessageV1().Messages("team-a").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			kept := map[string]bool{}
+			for _, message := range list.Items {
+				kept[message.ObjectMeta.Name] = true
+			}
+			if !kept["standup-5"] {
+				t.Error("pending Message was pruned")
+			}
+			for i := 0; i < 5; i++ {
+				want := i >= 5-test.wantKept
+				if got := kept[fmt.Sprintf("standup-%d", i)]; got != want {
+					t.Errorf("standup-%d kept: %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+	"github.com/yasker/example-crd/sink"
+)
+
+// topicIndex indexes Messages and Subscriptions by "<namespace>/<topic>".
+const topicIndex = "topic"
+
+func indexMessageByTopic(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.Topic == "" {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + message.Spec.Topic}, nil
+}
+
+func indexSubscriptionByTopic(obj interface{}) ([]string, error) {
+	subscription, ok := obj.(*messagev1.Subscription)
+	if !ok {
+		return nil, nil
+	}
+	return []string{subscription.ObjectMeta.Namespace + "/" + subscription.Spec.Topic}, nil
+}
+
+func (c *MessageController) watchSubscriptions(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().Subscriptions()
+	err := informer.Informer().AddIndexers(cache.Indexers{topicIndex: indexSubscriptionByTopic})
+	if err != nil {
+		return err
+	}
+
+	// Creation timestamps have a resolution of one second, so a Message
+	// published in the same second as a new Subscription may have been
+	// reconciled before the Subscription showed up in the cache.
+	_, err = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onSubscriptionChange,
+		// A fixed recipient gets the Messages its sink failed to be built
+		// for.
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj.(*messagev1.Subscription).ObjectMeta.Generation != newObj.(*messagev1.Subscription).ObjectMeta.Generation {
+				c.onSubscriptionChange(newObj)
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.subscriptionIndex = informer.Informer().GetIndexer()
+	return nil
+}
+
+func (c *MessageController) onSubscriptionChange(obj interface{}) {
+	topic, err := indexSubscriptionByTopic(obj)
+	if err != nil || len(topic) == 0 {
+		return
+	}
+	messages, err := c.messageIndex.ByIndex(topicIndex, topic[0])
+	if err != nil {
+		fmt.Printf("ERROR listing Messages of topic %s: %v\n", topic[0], err)
+		return
+	}
+	for _, message := range messages {
+		c.enqueue(message)
+	}
+}
+
+// topicRoute fans message out to the recipients of the Subscriptions to its
+// topic that match it, and records how many there are. A recipient whose
+// sink can't be built gets a Failed delivery instead, and its error in the
+// route.
+func (c *MessageController) topicRoute(message *messagev1.Message) route {
+	subscriptions, err := c.subscriptionIndex.ByIndex(topicIndex, message.ObjectMeta.Namespace+"/"+message.Spec.Topic)
+	if err != nil {
+		fmt.Printf("ERROR listing Subscriptions of topic %s: %v\n", message.Spec.Topic, err)
+	}
+
+	var r route
+	var subscribers int32
+	for _, obj := range subscriptions {
+		subscription := obj.(*messagev1.Subscription)
+		matches, err := subscriptionMatches(subscription, message)
+		if err != nil {
+			fmt.Printf("ERROR matching Subscription %s/%s: %v\n", subscription.ObjectMeta.Namespace, subscription.ObjectMeta.Name, err)
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		name := sink.SubscriptionName(subscription)
+		subscribers++
+		subscriptionRoute, err := c.routes.get(name, subscription, func() (route, error) {
+			s, err := sink.ForSubscription(subscription, c.getSecret)
+			if err != nil {
+				return route{}, err
+			}
+			return route{sinks: []sink.Sink{s}}, nil
+		})
+		if err != nil {
+			fmt.Printf("ERROR building sink of Subscription %s/%s: %v\n", subscription.ObjectMeta.Namespace, subscription.ObjectMeta.Name, err)
+			if delivery := findDelivery(&message.Status, name); delivery != nil && delivery.State == messagev1.DeliveryStateDelivered {
+				continue
+			}
+			setDelivery(&message.Status, messagev1.MessageDelivery{
+				Sink:     name,
+				State:    messagev1.DeliveryStateFailed,
+				Error:    fmt.Sprintf("building sink of Subscription %s: %v", subscription.ObjectMeta.Name, err),
+				Attempts: deliveryAttempts(findDelivery(&message.Status, name)),
+			})
+			r.errs = append(r.errs, fmt.Errorf("building sink of Subscription %s: %v", subscription.ObjectMeta.Name, err))
+			continue
+		}
+		r.sinks = append(r.sinks, subscriptionRoute.sinks...)
+	}
+
+	message.Status.Subscribers = subscribers
+	return r
+}
+
+func subscriptionMatches(subscription *messagev1.Subscription, message *messagev1.Message) (bool, error) {
+	if subscription.ObjectMeta.CreationTimestamp.After(message.ObjectMeta.CreationTimestamp.Time) {
+		return false, nil
+	}
+
+	filter := subscription.Spec.Filter
+	if filter == nil {
+		return true, nil
+	}
+	if filter.Urgent != nil && *filter.Urgent != message.Spec.Urgent {
+		return false, nil
+	}
+	if filter.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(filter.Selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(message.ObjectMeta.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	informers "github.com/yasker/example-crd/pkg/client/informers/externalversions"
+)
+
+// templateIndex indexes Messages by "<namespace>/<name>" of the
+// MessageTemplate they reference, so that a template change requeues the
+// Messages using it.
+const templateIndex = "template"
+
+// maxRenderedLength bounds the text a template may render, so a template
+// can't blow up the size of the Message status.
+const maxRenderedLength = 16 * 1024
+
+// Bounds of the execution of a template, so that a template looping or
+// recursing for long, such as {{range 300000000}}{{end}}, can't hold up a
+// worker. A step is an iteration of a range or an invocation of a template.
+const (
+	maxRenderSteps = 10000
+	renderTimeout  = time.Second
+)
+
+// stepFunc is the name of the function the steps of a template call. It is
+// not an identifier templates can use by mistake.
+const stepFunc = "_step"
+
+// templateFuncs are the only functions templates can call besides the
+// text/template builtins. None of them has side effects or reaches outside
+// the template's parameters.
+var templateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    replace,
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"quote":      quote,
+	"default": func(def, s string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	},
+}
+
+var errRenderedTooLong = fmt.Errorf("rendered text exceeds %d bytes", maxRenderedLength)
+
+// replace is strings.ReplaceAll, refusing to return more than
+// maxRenderedLength bytes, since replacing can multiply the length of s.
+func replace(old, new, s string) (string, error) {
+	n := strings.Count(s, old)
+	if len(s)+n*(len(new)-len(old)) > maxRenderedLength {
+		return "", errRenderedTooLong
+	}
+	return strings.ReplaceAll(s, old, new), nil
+}
+
+// quote is strconv.Quote, refusing to quote more than maxRenderedLength
+// bytes, since quoting can quadruple the length of s.
+func quote(s string) (string, error) {
+	if len(s) > maxRenderedLength {
+		return "", errRenderedTooLong
+	}
+	return strconv.Quote(s), nil
+}
+
+func indexByTemplate(obj interface{}) ([]string, error) {
+	message, ok := obj.(*messagev1.Message)
+	if !ok || message.Spec.TemplateRef == nil {
+		return nil, nil
+	}
+	return []string{message.ObjectMeta.Namespace + "/" + message.Spec.TemplateRef.Name}, nil
+}
+
+func (c *MessageController) watchTemplates(factory informers.SharedInformerFactory) error {
+	informer := factory.Message().V1().MessageTemplates()
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.onTemplateChange,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.onTemplateChange(newObj)
+		},
+		DeleteFunc: c.onTemplateChange,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.templateLister = informer.Lister()
+	return nil
+}
+
+func (c *MessageController) onTemplateChange(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		fmt.Printf("ERROR getting key for %#v: %v\n", obj, err)
+		return
+	}
+	messages, err := c.messageIndex.ByIndex(templateIndex, key)
+	if err != nil {
+		fmt.Printf("ERROR listing Messages of MessageTemplate %s: %v\n", key, err)
+		return
+	}
+	for _, message := range messages {
+		c.enqueue(message)
+	}
+}
+
+// render renders the text of message from the template it references into
+// its status, recording the outcome in its TemplateRendered condition. It
+// returns false if message can't be broadcast until its template or
+// parameters change. Broadcasted Messages keep the text they were sent with.
+func (c *MessageController) render(message *messagev1.Message) bool {
+	ref := message.Spec.TemplateRef
+	if ref == nil {
+		return true
+	}
+	if message.Status.State == messagev1.MessageStateBroadcasted && message.Status.Rendered != "" {
+		return true
+	}
+
+	tmpl, err := c.templateLister.MessageTemplates(message.ObjectMeta.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		setCondition(message, messagev1.MessageConditionTemplateRendered, metav1.ConditionFalse,
+			messagev1.MessageReasonTemplateNotFound, fmt.Sprintf("MessageTemplate %s does not exist", ref.Name))
+		return false
+	}
+	if err != nil {
+		setCondition(message, messagev1.MessageConditionTemplateRendered, metav1.ConditionFalse,
+			messagev1.MessageReasonTemplateInvalid, err.Error())
+		return false
+	}
+
+	params, reason, err := templateParameters(tmpl, ref.Parameters)
+	if err == nil {
+		reason = messagev1.MessageReasonTemplateInvalid
+		message.Status.Rendered, err = renderTemplate(tmpl, params)
+	}
+	if err != nil {
+		setCondition(message, messagev1.MessageConditionTemplateRendered, metav1.ConditionFalse, reason, err.Error())
+		return false
+	}
+
+	setCondition(message, messagev1.MessageConditionTemplateRendered, metav1.ConditionTrue,
+		messagev1.MessageReasonTemplateRendered, fmt.Sprintf("Rendered from MessageTemplate %s", ref.Name))
+	return true
+}
+
+// templateParameters checks values against the parameters tmpl declares and
+// fills in their defaults. On error, it also returns the condition reason
+// describing it.
+func templateParameters(tmpl *messagev1.MessageTemplate, values map[string]string) (map[string]string, string, error) {
+	declared := make(map[string]bool, len(tmpl.Spec.Parameters))
+	params := make(map[string]string, len(tmpl.Spec.Parameters))
+	var missing []string
+	for _, p := range tmpl.Spec.Parameters {
+		declared[p.Name] = true
+		value, ok := values[p.Name]
+		switch {
+		case ok:
+			params[p.Name] = value
+		case p.Default != "":
+			params[p.Name] = p.Default
+		case p.Required:
+			missing = append(missing, p.Name)
+		default:
+			params[p.Name] = ""
+		}
+	}
+
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, messagev1.MessageReasonParameterUnknown,
+			fmt.Errorf("MessageTemplate %s has no parameters %s", tmpl.ObjectMeta.Name, strings.Join(unknown, ", "))
+	}
+	if len(missing) != 0 {
+		return nil, messagev1.MessageReasonParameterMissing,
+			fmt.Errorf("missing required parameters %s of MessageTemplate %s", strings.Join(missing, ", "), tmpl.ObjectMeta.Name)
+	}
+	return params, "", nil
+}
+
+// renderTemplate executes the body of tmpl with params. Referring to a
+// parameter the template doesn't declare is an error, and so is taking more
+// than maxRenderSteps steps or renderTimeout.
+func renderTemplate(tmpl *messagev1.MessageTemplate, params map[string]string) (string, error) {
+	steps := 0
+	deadline := time.Now().Add(renderTimeout)
+	funcs := template.FuncMap{
+		stepFunc: func() (string, error) {
+			steps++
+			if steps > maxRenderSteps {
+				return "", errTooManySteps
+			}
+			if time.Now().After(deadline) {
+				return "", errRenderTimeout
+			}
+			return "", nil
+		},
+	}
+	t, err := template.New(tmpl.ObjectMeta.Name).
+		Option("missingkey=error").
+		Funcs(templateFuncs).
+		Funcs(funcs).
+		Parse(tmpl.Spec.Body)
+	if err != nil {
+		return "", err
+	}
+	for _, defined := range t.Templates() {
+		if defined.Tree != nil {
+			addSteps(defined.Tree.Root, true)
+		}
+	}
+
+	out := &limitedBuffer{limit: maxRenderedLength}
+	if err := t.Execute(out, params); err != nil {
+		for _, bound := range []error{errRenderedTooLong, errTooManySteps, errRenderTimeout} {
+			if errors.Is(err, bound) {
+				return "", bound
+			}
+		}
+		return "", err
+	}
+	return out.String(), nil
+}
+
+var (
+	errTooManySteps  = fmt.Errorf("template takes more than %d steps", maxRenderSteps)
+	errRenderTimeout = fmt.Errorf("template takes longer than %v", renderTimeout)
+)
+
+// addSteps makes list call stepFunc first if it is the body of a template,
+// and makes the bodies of the ranges in list call it on every iteration.
+func addSteps(list *parse.ListNode, step bool) {
+	if list == nil {
+		return
+	}
+	for _, node := range list.Nodes {
+		switch node := node.(type) {
+		case *parse.IfNode:
+			addSteps(node.List, false)
+			addSteps(node.ElseList, false)
+		case *parse.WithNode:
+			addSteps(node.List, false)
+			addSteps(node.ElseList, false)
+		case *parse.RangeNode:
+			addSteps(node.List, true)
+			addSteps(node.ElseList, false)
+		case *parse.ListNode:
+			addSteps(node, false)
+		}
+	}
+	if step {
+		call := &parse.ActionNode{
+			NodeType: parse.NodeAction,
+			Pos:      list.Pos,
+			Pipe: &parse.PipeNode{
+				NodeType: parse.NodePipe,
+				Pos:      list.Pos,
+				Cmds: []*parse.CommandNode{{
+					NodeType: parse.NodeCommand,
+					Pos:      list.Pos,
+					Args:     []parse.Node{&parse.IdentifierNode{NodeType: parse.NodeIdentifier, Pos: list.Pos, Ident: stepFunc}},
+				}},
+			},
+		}
+		list.Nodes = append([]parse.Node{call}, list.Nodes...)
+	}
+}
+
+// limitedBuffer is a bytes.Buffer that fails writes past limit bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errRenderedTooLong
+	}
+	return b.Buffer.Write(p)
+}
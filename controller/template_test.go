@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		params  map[string]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "parameters and functions",
+			body:   `{{upper .service}} is {{default "down" .state}}{{range $k, $v := $}} {{$k}}={{quote $v}}{{end}}`,
+			params: map[string]string{"service": "db", "state": ""},
+			want:   `DB is down service="db" state=""`,
+		},
+		{
+			name:   "nested ranges",
+			body:   `{{range 3}}{{range 2}}.{{end}}{{end}}`,
+			params: map[string]string{},
+			want:   "......",
+		},
+		{
+			name:    "long range",
+			body:    `{{range 300000000}}{{end}}`,
+			params:  map[string]string{},
+			wantErr: errTooManySteps,
+		},
+		{
+			name:    "nested long ranges",
+			body:    `{{range 1000}}{{range 1000}}{{end}}{{end}}`,
+			params:  map[string]string{},
+			wantErr: errTooManySteps,
+		},
+		{
+			name:    "recursion",
+			body:    `{{define "a"}}{{template "a"}}{{template "a"}}{{end}}{{template "a"}}`,
+			params:  map[string]string{},
+			wantErr: errTooManySteps,
+		},
+		{
+			name:    "amplifying replace",
+			body:    `{{replace "" .s .s}}`,
+			params:  map[string]string{"s": strings.Repeat("x", 1000)},
+			wantErr: errRenderedTooLong,
+		},
+		{
+			name:    "long output",
+			body:    `{{range 10000}}{{$.s}}{{end}}`,
+			params:  map[string]string{"s": "0123456789"},
+			wantErr: errRenderedTooLong,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl := &messagev1.MessageTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "outage"},
+				Spec:       messagev1.MessageTemplateSpec{Body: test.body},
+			}
+			start := time.Now()
+			got, err := renderTemplate(tmpl, test.params)
+			if elapsed := time.Since(start); elapsed > renderTimeout {
+				t.Errorf("rendering took %v, longer than %v", elapsed, renderTimeout)
+			}
+			if err != test.wantErr {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/metrics"
+)
+
+// RateLimits are the token buckets deliveries are throttled with, on top of
+// the rate limit of the BroadcastChannel a Message goes through. Nil limits
+// don't throttle.
+type RateLimits struct {
+	// Global is shared by every delivery of the controller.
+	Global *messagev1.RateLimit
+	// Namespace applies to the deliveries of each namespace separately.
+	Namespace *messagev1.RateLimit
+	// Sink applies to the deliveries to each sink separately.
+	Sink *messagev1.RateLimit
+}
+
+// ParseRateLimit parses a rate limit in the form "LIMIT[/PERIOD][:BURST]",
+// e.g. "100/1m:20" for 100 deliveries a minute in bursts of up to 20.
+func ParseRateLimit(s string) (*messagev1.RateLimit, error) {
+	limit := &messagev1.RateLimit{}
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		burst, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid burst in rate limit %q: %v", s, err)
+		}
+		limit.Burst = int32(burst)
+		s = s[:i]
+	}
+	if i := strings.Index(s, "/"); i >= 0 {
+		period, err := time.ParseDuration(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid period in rate limit %q: %v", s, err)
+		}
+		if period <= 0 {
+			return nil, fmt.Errorf("invalid period in rate limit %q: must be positive", s)
+		}
+		limit.Period = &metav1.Duration{Duration: period}
+		s = s[:i]
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid limit %q", s)
+	}
+	limit.Limit = int32(n)
+	return limit, nil
+}
+
+// throttle holds the limiters of RateLimits. The global limiter is created
+// when the controller starts, namespace and sink limiters on first use.
+type throttle struct {
+	lock       sync.Mutex
+	global     *rate.Limiter
+	namespaces map[string]*rate.Limiter
+	sinks      map[string]*rate.Limiter
+}
+
+func (t *throttle) limiter(limiters *map[string]*rate.Limiter, key string, limit *messagev1.RateLimit) *rate.Limiter {
+	if limit == nil {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if *limiters == nil {
+		*limiters = make(map[string]*rate.Limiter)
+	}
+	limiter, ok := (*limiters)[key]
+	if !ok {
+		limiter = newLimiter(limit)
+		(*limiters)[key] = limiter
+	}
+	return limiter
+}
+
+// scopedLimiter is a limiter and the scope it throttles.
+type scopedLimiter struct {
+	scope   string
+	limiter *rate.Limiter
+}
+
+// reserve takes a token from every rate limit a delivery of message to the
+// sink called sinkName over r is subject to. If one of them has no token
+// left, it takes none and returns how long to wait until they all have one,
+// and the scope of the rate limit that waits the longest.
+func (c *MessageController) reserve(message *messagev1.Message, r route, sinkName string) (time.Duration, string) {
+	limiters := []scopedLimiter{
+		{metrics.ThrottleGlobal, c.throttle.global},
+		{metrics.ThrottleNamespace, c.throttle.limiter(&c.throttle.namespaces, message.ObjectMeta.Namespace, c.RateLimits.Namespace)},
+		{metrics.ThrottleChannel, r.limiter},
+		{metrics.ThrottleSink, c.throttle.limiter(&c.throttle.sinks, sinkName, c.RateLimits.Sink)},
+	}
+
+	var reservations []*rate.Reservation
+	var delay time.Duration
+	var scope string
+	for _, l := range limiters {
+		if l.limiter == nil {
+			continue
+		}
+		reservation := l.limiter.Reserve()
+		reservations = append(reservations, reservation)
+		if d := reservation.Delay(); d > delay {
+			delay, scope = d, l.scope
+		}
+	}
+	if delay > 0 {
+		for _, reservation := range reservations {
+			reservation.Cancel()
+		}
+	}
+	return delay, scope
+}
+
+var throttledReasons = map[string]string{
+	metrics.ThrottleGlobal:    messagev1.MessageReasonGlobalRateLimited,
+	metrics.ThrottleNamespace: messagev1.MessageReasonNamespaceRateLimited,
+	metrics.ThrottleChannel:   messagev1.MessageReasonChannelRateLimited,
+	metrics.ThrottleSink:      messagev1.MessageReasonSinkRateLimited,
+}
+
+// setThrottled records in the Throttled condition of message that its
+// delivery was postponed by delay by the rate limit of scope.
+func setThrottled(message *messagev1.Message, scope string, delay time.Duration) {
+	metrics.ThrottledDeliveries.WithLabelValues(scope).Inc()
+	metrics.ThrottleDelay.WithLabelValues(scope).Observe(delay.Seconds())
+	// The message stays the same while throttled, so that the status is
+	// not written again every time the delivery is postponed.
+	setCondition(message, messagev1.MessageConditionThrottled, metav1.ConditionTrue,
+		throttledReasons[scope], fmt.Sprintf("Delivery is postponed by the %s rate limit", scope))
+}
+
+// clearThrottled records that message is no longer throttled, if it was.
+func clearThrottled(message *messagev1.Message) {
+	condition := meta.FindStatusCondition(message.Status.Conditions, messagev1.MessageConditionThrottled)
+	if condition == nil || condition.Status == metav1.ConditionFalse {
+		return
+	}
+	setCondition(message, messagev1.MessageConditionThrottled, metav1.ConditionFalse,
+		messagev1.MessageReasonNotThrottled, "Delivery is no longer rate limited")
+}
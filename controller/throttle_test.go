@@ -0,0 +1,65 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		value      string
+		want       messagev1.RateLimit
+		wantPeriod time.Duration
+		wantErr    bool
+	}{
+		{value: "100", want: messagev1.RateLimit{Limit: 100}},
+		{value: "100/1m", want: messagev1.RateLimit{Limit: 100}, wantPeriod: time.Minute},
+		{value: "100:20", want: messagev1.RateLimit{Limit: 100, Burst: 20}},
+		{value: "100/1m:20", want: messagev1.RateLimit{Limit: 100, Burst: 20}, wantPeriod: time.Minute},
+		{value: "0", wantErr: true},
+		{value: "-1/1m", wantErr: true},
+		{value: "100/0s", wantErr: true},
+		{value: "100/-1m", wantErr: true},
+		{value: "100/minute", wantErr: true},
+		{value: "100:many", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseRateLimit(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want one: %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Limit != test.want.Limit || got.Burst != test.want.Burst {
+				t.Errorf("got limit %d and burst %d, want %d and %d", got.Limit, got.Burst, test.want.Limit, test.want.Burst)
+			}
+			var period time.Duration
+			if got.Period != nil {
+				period = got.Period.Duration
+			}
+			if period != test.wantPeriod {
+				t.Errorf("got period %v, want %v", period, test.wantPeriod)
+			}
+		})
+	}
+}
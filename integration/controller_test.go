@@ -0,0 +1,215 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/client"
+	"github.com/yasker/example-crd/controller"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+	"github.com/yasker/example-crd/sink"
+)
+
+// recordingSink records the Messages delivered to it.
+type recordingSink struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Deliver(ctx context.Context, delivery *sink.Delivery) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.messages = append(s.messages, delivery.Message.ObjectMeta.Namespace+"/"+delivery.Message.ObjectMeta.Name)
+	return nil
+}
+
+func (s *recordingSink) delivered() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// installCRDs installs the CRDs as main does.
+func installCRDs(ctx context.Context, t *testing.T) {
+	t.Helper()
+	extensions, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, apply := range []func(context.Context, apiextensionsclient.Interface) (*apiextensionsv1.CustomResourceDefinition, error){
+		client.CreateCustomResourceDefinition,
+		client.CreateBroadcastChannelCustomResourceDefinition,
+		client.CreateSubscriptionCustomResourceDefinition,
+		client.CreateMessageTemplateCustomResourceDefinition,
+		client.CreateMessageAcknowledgementCustomResourceDefinition,
+		client.CreateEscalationPolicyCustomResourceDefinition,
+		client.CreateDeadLetterCustomResourceDefinition,
+	} {
+		crd, err := apply(ctx, extensions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Applying an existing CRD updates it.
+		if _, err := apply(ctx, extensions); err != nil {
+			t.Fatalf("applying CRD %s again: %v", crd.ObjectMeta.Name, err)
+		}
+	}
+}
+
+func TestController(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	installCRDs(ctx, t)
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageClient, err := messageClientset.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &recordingSink{}
+	c := &controller.MessageController{
+		MessageClient: messageClient,
+		KubeClient:    kubeClient,
+		Sinks:         []sink.Sink{s},
+	}
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- c.Run(runCtx)
+	}()
+	defer func() {
+		stop()
+		<-done
+	}()
+
+	tests := []struct {
+		name      string
+		spec      messagev1.MessageSpec
+		wantState messagev1.MessageState
+	}{
+		{name: "broadcast", spec: messagev1.MessageSpec{Context: "disk /var is 95% full"}, wantState: messagev1.MessageStateBroadcasted},
+		{name: "missing-channel", spec: messagev1.MessageSpec{ChannelRef: &messagev1.ChannelReference{Name: "pager"}}, wantState: messagev1.MessageStateCreated},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			messages := messageClient.MessageV1().Messages("team-a")
+			created, err := messages.Create(ctx, &messagev1.Message{
+				ObjectMeta: metav1.ObjectMeta{Name: test.name},
+				Spec:       test.spec,
+			}, metav1.CreateOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var message *messagev1.Message
+			err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+				message, err = messages.Get(ctx, test.name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return message.Status.State == test.wantState, nil
+			})
+			if err != nil {
+				t.Fatalf("Message did not reach state %s: %v, status %+v", test.wantState, err, message.Status)
+			}
+			if message.ObjectMeta.Generation != created.ObjectMeta.Generation {
+				t.Errorf("spec changed from generation %d to %d, want status written through its subresource only",
+					created.ObjectMeta.Generation, message.ObjectMeta.Generation)
+			}
+		})
+	}
+
+	if got := s.delivered(); len(got) != 1 || got[0] != "team-a/broadcast" {
+		t.Errorf("got deliveries %q, want only team-a/broadcast", got)
+	}
+}
+
+func TestAcknowledgedByVerified(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	installCRDs(ctx, t)
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CreateMessageAcknowledgementAdmissionPolicy(ctx, kubeClient); err != nil {
+		t.Fatal(err)
+	}
+	messageClient, err := messageClientset.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acknowledgements := messageClient.MessageV1().MessageAcknowledgements("default")
+	acknowledgement := func(by string) *messagev1.MessageAcknowledgement {
+		return &messagev1.MessageAcknowledgement{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "disk-full-"},
+			Spec: messagev1.MessageAcknowledgementSpec{
+				MessageRef:     messagev1.MessageReference{Name: "disk-full"},
+				AcknowledgedBy: by,
+			},
+		}
+	}
+	// The API server picks the policy up in the background.
+	err = wait.PollUntilContextTimeout(ctx, 200*time.Millisecond, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+		created, err := acknowledgements.Create(ctx, acknowledgement("someone-else"), metav1.CreateOptions{})
+		if apierrors.IsForbidden(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, nil
+		}
+		return false, acknowledgements.Delete(ctx, created.ObjectMeta.Name, metav1.DeleteOptions{})
+	})
+	if err != nil {
+		t.Fatalf("acknowledging as someone else is still admitted: %v", err)
+	}
+
+	created, err := acknowledgements.Create(ctx, acknowledgement("admin"), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("acknowledging as the authenticated user: %v", err)
+	}
+	created.Spec.AcknowledgedBy = "someone-else"
+	if _, err := acknowledgements.Update(ctx, created, metav1.UpdateOptions{}); err == nil {
+		t.Error("acknowledgedBy changed after the acknowledgement was created")
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// config reaches the kube-apiserver of the test environment as a member
+// of system:masters.
+var config *rest.Config
+
+func TestMain(m *testing.M) {
+	assets := os.Getenv("KUBEBUILDER_ASSETS")
+	if assets == "" {
+		fmt.Println("KUBEBUILDER_ASSETS is not set, skipping the integration tests")
+		os.Exit(0)
+	}
+	env, err := startEnvironment(assets)
+	if err != nil {
+		fmt.Printf("ERROR starting the test environment: %v\n", err)
+		os.Exit(1)
+	}
+	config = env.config
+	code := m.Run()
+	env.stop()
+	os.Exit(code)
+}
+
+// environment is an etcd and a kube-apiserver storing into it.
+type environment struct {
+	dir       string
+	etcd      *exec.Cmd
+	apiserver *exec.Cmd
+	config    *rest.Config
+}
+
+func startEnvironment(assets string) (*environment, error) {
+	dir, err := os.MkdirTemp("", "integration")
+	if err != nil {
+		return nil, err
+	}
+	env := &environment{dir: dir}
+	if err := env.start(assets); err != nil {
+		env.stop()
+		return nil, err
+	}
+	return env, nil
+}
+
+func (e *environment) start(assets string) error {
+	etcdPort, err := freePort()
+	if err != nil {
+		return err
+	}
+	peerPort, err := freePort()
+	if err != nil {
+		return err
+	}
+	etcdURL := "http://127.0.0.1:" + strconv.Itoa(etcdPort)
+	e.etcd, err = e.run(filepath.Join(assets, "etcd"),
+		"--data-dir="+filepath.Join(e.dir, "etcd"),
+		"--listen-client-urls="+etcdURL,
+		"--advertise-client-urls="+etcdURL,
+		"--listen-peer-urls=http://127.0.0.1:"+strconv.Itoa(peerPort),
+		"--unsafe-no-fsync=true",
+	)
+	if err != nil {
+		return err
+	}
+
+	keyFile, err := e.writeServiceAccountKey()
+	if err != nil {
+		return err
+	}
+	token, tokenFile, err := e.writeTokenFile()
+	if err != nil {
+		return err
+	}
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	e.apiserver, err = e.run(filepath.Join(assets, "kube-apiserver"),
+		"--etcd-servers="+etcdURL,
+		"--cert-dir="+filepath.Join(e.dir, "certs"),
+		"--bind-address=127.0.0.1",
+		"--advertise-address=127.0.0.1",
+		"--secure-port="+strconv.Itoa(port),
+		"--token-auth-file="+tokenFile,
+		"--authorization-mode=RBAC",
+		"--service-account-issuer=https://kubernetes.default.svc",
+		"--service-account-key-file="+keyFile,
+		"--service-account-signing-key-file="+keyFile,
+		"--service-cluster-ip-range=10.0.0.0/24",
+		"--disable-admission-plugins=ServiceAccount",
+	)
+	if err != nil {
+		return err
+	}
+
+	e.config = &rest.Config{
+		Host:            "https://127.0.0.1:" + strconv.Itoa(port),
+		BearerToken:     token,
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+	}
+	return e.waitReady()
+}
+
+// run starts the binary at path with args, logging to a file next to the
+// data of the environment.
+func (e *environment) run(path string, args ...string) (*exec.Cmd, error) {
+	log, err := os.Create(filepath.Join(e.dir, filepath.Base(path)+".log"))
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	if err := cmd.Start(); err != nil {
+		log.Close()
+		return nil, fmt.Errorf("starting %s: %v", path, err)
+	}
+	return cmd, nil
+}
+
+func (e *environment) writeServiceAccountKey() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(e.dir, "sa.key")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return path, os.WriteFile(path, data, 0600)
+}
+
+// writeTokenFile writes the static token of an admin in system:masters.
+func (e *environment) writeTokenFile() (string, string, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(secret)
+	path := filepath.Join(e.dir, "tokens.csv")
+	line := token + ",admin,admin,system:masters\n"
+	return token, path, os.WriteFile(path, []byte(line), 0600)
+}
+
+// waitReady waits for the kube-apiserver to report ready.
+func (e *environment) waitReady() error {
+	client, err := rest.HTTPClientFor(e.config)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(time.Minute)
+	for {
+		resp, err := client.Get(e.config.Host + "/readyz")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("kube-apiserver not ready after a minute, see the logs in %s", e.dir)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func (e *environment) stop() {
+	for _, cmd := range []*exec.Cmd{e.apiserver, e.etcd} {
+		if cmd == nil || cmd.Process == nil {
+			continue
+		}
+		cmd.Process.Signal(syscall.SIGTERM)
+		cmd.Wait()
+	}
+	os.RemoveAll(e.dir)
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
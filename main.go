@@ -19,19 +19,35 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
-	kubeclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
 	// Uncomment the following line to load the gcp plugin (only required to authenticate against GKE clusters).
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
+	broadcastv1 "github.com/yasker/example-crd/apis/broadcast/v1"
 	messagev1 "github.com/yasker/example-crd/apis/message/v1"
 	"github.com/yasker/example-crd/client"
 	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+	"github.com/yasker/example-crd/server"
+	"github.com/yasker/example-crd/sink"
 
 	controller "github.com/yasker/example-crd/controller"
 )
@@ -39,50 +55,148 @@ import (
 func main() {
 	masterURL := flag.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	kubeconfig := flag.String("kubeconfig", "", "Path to a kube config. Only required if out-of-cluster.")
+	resyncPeriod := flag.Duration("resync-period", 30*time.Second, "How often every Message is reconciled again to repair drifted status. 0 disables the resync.")
+	workers := flag.Int("workers", 4, "How many Messages are reconciled in parallel. Messages with the same ordering key are still delivered one at a time.")
+	dedupWindow := flag.Duration("dedup-window", 10*time.Minute, "How long after a Message another one with the same idempotency key is suppressed, unless its channel sets its own. 0 disables deduplication.")
+	ackTimeout := flag.Duration("ack-timeout", 15*time.Minute, "How long an urgent Message may go unacknowledged before it is escalated. 0 disables acknowledgement tracking.")
+	var rateLimits controller.RateLimits
+	rateLimitFlag := func(limit **messagev1.RateLimit) func(string) error {
+		return func(s string) (err error) {
+			*limit, err = controller.ParseRateLimit(s)
+			return err
+		}
+	}
+	flag.Func("global-rate-limit", "Rate limit of all deliveries, as LIMIT[/PERIOD][:BURST], e.g. 100/1m:20.", rateLimitFlag(&rateLimits.Global))
+	flag.Func("namespace-rate-limit", "Rate limit of the deliveries of each namespace, as LIMIT[/PERIOD][:BURST].", rateLimitFlag(&rateLimits.Namespace))
+	flag.Func("sink-rate-limit", "Rate limit of the deliveries to each sink, as LIMIT[/PERIOD][:BURST].", rateLimitFlag(&rateLimits.Sink))
+	breakerThreshold := flag.Int("breaker-failure-threshold", 5, "How many deliveries to a sink fail in a row before its circuit breaker opens. 0 disables the circuit breakers.")
+	breakerCooldown := flag.Duration("breaker-cooldown", 30*time.Second, "How long a circuit breaker stays open before a delivery probes its sink again.")
+	maxAttempts := flag.Int("max-delivery-attempts", 30, "How many times delivering a Message to a sink may fail before it is dead-lettered. 0 retries forever.")
+	deadLetterNamespace := flag.String("dead-letter-namespace", "", "Namespace DeadLetters are created in. Defaults to the namespace of the failed Message.")
+	metricsAddress := flag.String("metrics-address", ":8080", "The address the Prometheus metrics, sink status, live feed and Alertmanager receiver endpoints bind to. Empty disables them.")
+	grpcAddress := flag.String("grpc-address", "", "The address the gRPC broadcast server binds to. Empty disables it.")
+	grpcCertFile := flag.String("grpc-tls-cert-file", "", "File holding the PEM encoded TLS certificate of the gRPC broadcast server, followed by its intermediates. Required with -grpc-address.")
+	grpcKeyFile := flag.String("grpc-tls-key-file", "", "File holding the PEM encoded TLS private key of the gRPC broadcast server. Required with -grpc-address.")
+	alertNamespace := flag.String("alert-namespace", "default", "Namespace of the Messages created from Alertmanager alerts without a namespace label.")
+	alertUrgentSeverities := flag.String("alert-urgent-severities", "critical", "Comma separated severity labels of the Alertmanager alerts whose Messages are urgent.")
+	alertTopic := flag.String("alert-topic", "", "Topic of the Messages created from Alertmanager alerts.")
+	alertChannel := flag.String("alert-channel", "", "BroadcastChannel of the Messages created from Alertmanager alerts.")
+	eventHistory := flag.Int("event-history", 100, "How many of the last Message events the live feed keeps for replay.")
 	flag.Parse()
 
+	if *eventHistory < 0 {
+		fmt.Fprintf(os.Stderr, "invalid value %d for flag -event-history: must not be negative\n", *eventHistory)
+		os.Exit(2)
+	}
+	// Clients send their bearer token to the gRPC server, so it is only
+	// served over TLS.
+	if *grpcAddress != "" && (*grpcCertFile == "" || *grpcKeyFile == "") {
+		fmt.Fprintln(os.Stderr, "flags -grpc-tls-cert-file and -grpc-tls-key-file are required with -grpc-address")
+		os.Exit(2)
+	}
+
+	if *metricsAddress != "" {
+		http.Handle("/metrics", promhttp.Handler())
+		go func() {
+			fmt.Printf("Metrics server stopped: %v\n", http.ListenAndServe(*metricsAddress, nil))
+		}()
+	}
+
 	// Create the client config. Use masterURL and kubeconfig if given, otherwise assume in-cluster.
 	config, err := clientcmd.BuildConfigFromFlags(*masterURL, *kubeconfig)
 	if err != nil {
 		panic(err)
 	}
 
-	kubeclient, err := kubeclient.NewForConfig(config)
+	kubeclient, err := apiextensionsclient.NewForConfig(config)
 	if err != nil {
 		panic(err)
 	}
 
-	// initialize custom resource using a CustomResourceDefinition if it does not exist
-	crd, err := client.CreateCustomResourceDefinition(kubeclient)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		panic(err)
+	// The controller and servers run until the process is asked to stop.
+	ctx, cancelFunc := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancelFunc()
+
+	// initialize the custom resources using CustomResourceDefinitions, and
+	// update the ones that exist to the current schema
+	for _, apply := range []func(context.Context, apiextensionsclient.Interface) (*apiextensionsv1.CustomResourceDefinition, error){
+		client.CreateCustomResourceDefinition,
+		client.CreateBroadcastChannelCustomResourceDefinition,
+		client.CreateSubscriptionCustomResourceDefinition,
+		client.CreateMessageTemplateCustomResourceDefinition,
+		client.CreateMessageAcknowledgementCustomResourceDefinition,
+		client.CreateEscalationPolicyCustomResourceDefinition,
+		client.CreateDeadLetterCustomResourceDefinition,
+	} {
+		crd, err := apply(ctx, kubeclient)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("CRD %v applied\n", crd.ObjectMeta.Name)
 	}
 
-	if apierrors.IsAlreadyExists(err) {
-		fmt.Println("CRD existed")
-	} else {
-		fmt.Printf("CRD %v registered\n", crd.ObjectMeta.Name)
+	crClient, err := messageClientset.NewForConfig(config)
+	if err != nil {
+		panic(err)
 	}
 
-	messageClient, messageScheme, err := client.NewClient(config)
+	coreClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		panic(err)
 	}
 
+	// The controller records acknowledgedBy as who acknowledged a Message,
+	// so the API server has to verify it.
+	if err := client.CreateMessageAcknowledgementAdmissionPolicy(ctx, coreClient); err != nil {
+		panic(err)
+	}
+	fmt.Println("MessageAcknowledgement admission policy applied")
+
 	// start a controller on instances of our custom resource
 	controller := controller.MessageController{
-		MessageClient: messageClient,
-		MessageScheme: messageScheme,
+		MessageClient:          crClient,
+		KubeClient:             coreClient,
+		ResyncPeriod:           *resyncPeriod,
+		Sinks:                  []sink.Sink{sink.NewLogSink("log")},
+		Workers:                *workers,
+		DedupWindow:            *dedupWindow,
+		RateLimits:             rateLimits,
+		BreakerThreshold:       *breakerThreshold,
+		BreakerCooldown:        *breakerCooldown,
+		MaxAttempts:            *maxAttempts,
+		DeadLetterNamespace:    *deadLetterNamespace,
+		AcknowledgementTimeout: *ackTimeout,
 	}
+	hub := server.NewHub(*eventHistory)
+	controller.EventHandlers = append(controller.EventHandlers, hub)
+	if *metricsAddress != "" {
+		http.Handle("/sinks", controller.SinkStatusHandler())
+		http.Handle("/feed", server.NewFeedHandler(hub, coreClient))
 
-	ctx, cancelFunc := context.WithCancel(context.Background())
-	defer cancelFunc()
-	go controller.Run(ctx)
-
-	crClient, err := messageClientset.NewForConfig(config)
-	if err != nil {
-		panic(err)
+		alertReceiver := server.NewAlertReceiver(crClient, coreClient)
+		alertReceiver.DefaultNamespace = *alertNamespace
+		alertReceiver.UrgentSeverities = strings.Split(*alertUrgentSeverities, ",")
+		alertReceiver.Topic = *alertTopic
+		alertReceiver.ChannelName = *alertChannel
+		http.Handle("/alertmanager", alertReceiver)
 	}
+	if *grpcAddress != "" {
+		listener, err := net.Listen("tcp", *grpcAddress)
+		if err != nil {
+			panic(err)
+		}
+		creds, err := credentials.NewServerTLSFromFile(*grpcCertFile, *grpcKeyFile)
+		if err != nil {
+			panic(err)
+		}
+		grpcServer := grpc.NewServer(grpc.Creds(creds))
+		broadcastv1.RegisterBroadcasterServer(grpcServer, server.NewBroadcasterServer(hub, crClient, coreClient))
+		go func() {
+			fmt.Printf("gRPC server stopped: %v\n", grpcServer.Serve(listener))
+		}()
+		defer grpcServer.Stop()
+	}
+	go controller.Run(ctx)
 
 	var result *messagev1.Message
 	// Create an instance of our custom resource
@@ -98,7 +212,7 @@ func main() {
 			State: messagev1.MessageStateCreated,
 		},
 	}
-	result, err = crClient.MessageV1().Messages(apiv1.NamespaceDefault).Create(firstMessage)
+	result, err = crClient.MessageV1().Messages(apiv1.NamespaceDefault).Create(ctx, firstMessage, metav1.CreateOptions{})
 	if err == nil {
 		fmt.Printf("CREATED: %#v\n", result)
 	} else if apierrors.IsAlreadyExists(err) {
@@ -119,7 +233,7 @@ func main() {
 			State: messagev1.MessageStateCreated,
 		},
 	}
-	result, err = crClient.MessageV1().Messages(apiv1.NamespaceDefault).Create(secondMessage)
+	result, err = crClient.MessageV1().Messages(apiv1.NamespaceDefault).Create(ctx, secondMessage, metav1.CreateOptions{})
 	if err == nil {
 		fmt.Printf("CREATED: %#v\n", result)
 	} else if apierrors.IsAlreadyExists(err) {
@@ -130,23 +244,25 @@ func main() {
 
 	// Poll until Message object is handled by controller and gets status
 	// updated to "Broadcasted"
-	err = client.WaitForMessageInstanceProcessed(messageClient, "firstmessage")
+	err = client.WaitForMessageInstanceProcessed(ctx, crClient, "firstmessage")
 	if err != nil {
 		panic(err)
 	}
 	fmt.Print("BROADCASTED first message!\n")
 
-	err = client.WaitForMessageInstanceProcessed(messageClient, "secondmessage")
+	err = client.WaitForMessageInstanceProcessed(ctx, crClient, "secondmessage")
 	if err != nil {
 		panic(err)
 	}
 	fmt.Print("BROADCASTED second message!\n")
 
 	// Fetch a list of our CRs
-	messageList := messagev1.MessageList{}
-	err = messageClient.Get().Resource(messagev1.MessageResourcePlural).Do().Into(&messageList)
+	messageList, err := crClient.MessageV1().Messages(apiv1.NamespaceAll).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf("LIST: %#v\n", messageList)
+
+	<-ctx.Done()
+	fmt.Println("Shutting down")
 }
@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DriftState means a Message was delivered to every sink but its
+	// status no longer said Broadcasted.
+	DriftState = "state"
+	// DriftDelivery means a Message was marked Broadcasted but a sink had
+	// no successful delivery record for it.
+	DriftDelivery = "delivery"
+)
+
+const (
+	// ThrottleGlobal is the rate limit shared by every delivery.
+	ThrottleGlobal = "global"
+	// ThrottleNamespace is the rate limit of the Message's namespace.
+	ThrottleNamespace = "namespace"
+	// ThrottleChannel is the rate limit of the Message's BroadcastChannel.
+	ThrottleChannel = "channel"
+	// ThrottleSink is the rate limit of the sink delivered to.
+	ThrottleSink = "sink"
+)
+
+const (
+	// BreakerClosed breakers let deliveries to their sink through.
+	BreakerClosed = "closed"
+	// BreakerOpen breakers park deliveries to their sink.
+	BreakerOpen = "open"
+	// BreakerHalfOpen breakers let one delivery through to probe whether
+	// their sink recovered.
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerStates are the states of a sink's circuit breaker.
+var BreakerStates = []string{BreakerClosed, BreakerOpen, BreakerHalfOpen}
+
+var (
+	// DriftRepairs counts the inconsistencies between a Message's status
+	// and its delivery records that the reconcile loop has repaired.
+	DriftRepairs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "message_controller_drift_repairs_total",
+			Help: "Number of Message status drifts repaired by the controller.",
+		},
+		[]string{"kind"},
+	)
+
+	// ThrottledDeliveries counts the deliveries postponed by a rate limit,
+	// by the scope of the rate limit.
+	ThrottledDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "message_controller_throttled_deliveries_total",
+			Help: "Number of Message deliveries postponed by a rate limit.",
+		},
+		[]string{"scope"},
+	)
+
+	// BreakerState is 1 for the current state of the circuit breaker of
+	// each sink and 0 for its other states.
+	BreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "message_controller_sink_breaker_state",
+			Help: "State of the circuit breaker of each sink.",
+		},
+		[]string{"sink", "state"},
+	)
+
+	// ThrottleDelay observes how long throttled deliveries were postponed.
+	ThrottleDelay = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "message_controller_throttle_delay_seconds",
+			Help:    "How long Message deliveries were postponed by a rate limit.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+		},
+		[]string{"scope"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(DriftRepairs, ThrottledDeliveries, ThrottleDelay, BreakerState)
+}
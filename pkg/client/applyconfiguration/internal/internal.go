@@ -0,0 +1,787 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package internal
+
+import (
+	fmt "fmt"
+	sync "sync"
+
+	typed "sigs.k8s.io/structured-merge-diff/v6/typed"
+)
+
+func Parser() *typed.Parser {
+	parserOnce.Do(func() {
+		var err error
+		parser, err = typed.NewParser(schemaYAML)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse schema: %v", err))
+		}
+	})
+	return parser
+}
+
+var parserOnce sync.Once
+var parser *typed.Parser
+var schemaYAML = typed.YAMLObject(`types:
+- name: com.github.yasker.example-crd.apis.message.v1.AcknowledgementPolicy
+  map:
+    fields:
+    - name: escalationChannelRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.ChannelReference
+    - name: timeout
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+- name: com.github.yasker.example-crd.apis.message.v1.AcknowledgementStatus
+  map:
+    fields:
+    - name: acknowledgedAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: acknowledgedBy
+      type:
+        scalar: string
+    - name: deadline
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: escalatedAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: state
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.BroadcastChannel
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.BroadcastChannelSpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.BroadcastChannelSpec
+  map:
+    fields:
+    - name: allowedNamespaces
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+    - name: dedupWindow
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+    - name: rateLimit
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.RateLimit
+    - name: settings
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: sink
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.ChannelReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.DeadLetter
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.DeadLetterSpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.DeadLetterMessage
+  map:
+    fields:
+    - name: creationTimestamp
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: labels
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: namespace
+      type:
+        scalar: string
+      default: ""
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageSpec
+      default: {}
+    - name: uid
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.DeadLetterSpec
+  map:
+    fields:
+    - name: attempts
+      type:
+        list:
+          elementType:
+            namedType: com.github.yasker.example-crd.apis.message.v1.DeliveryAttempt
+          elementRelationship: atomic
+    - name: droppedAttempts
+      type:
+        scalar: numeric
+    - name: failedAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: message
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.DeadLetterMessage
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.DeliveryAttempt
+  map:
+    fields:
+    - name: error
+      type:
+        scalar: string
+      default: ""
+    - name: sink
+      type:
+        scalar: string
+      default: ""
+    - name: time
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+- name: com.github.yasker.example-crd.apis.message.v1.EscalationPolicy
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.EscalationPolicySpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.EscalationPolicyReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.EscalationPolicySpec
+  map:
+    fields:
+    - name: steps
+      type:
+        list:
+          elementType:
+            namedType: com.github.yasker.example-crd.apis.message.v1.EscalationStep
+          elementRelationship: atomic
+    - name: undeliveredAfter
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+- name: com.github.yasker.example-crd.apis.message.v1.EscalationStatus
+  map:
+    fields:
+    - name: lastEscalatedAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: nextAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: policy
+      type:
+        scalar: string
+      default: ""
+    - name: reason
+      type:
+        scalar: string
+      default: ""
+    - name: repeats
+      type:
+        scalar: numeric
+    - name: state
+      type:
+        scalar: string
+      default: ""
+    - name: step
+      type:
+        scalar: numeric
+      default: 0
+- name: com.github.yasker.example-crd.apis.message.v1.EscalationStep
+  map:
+    fields:
+    - name: channelRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.ChannelReference
+      default: {}
+    - name: delay
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+    - name: repeat
+      type:
+        scalar: numeric
+- name: com.github.yasker.example-crd.apis.message.v1.Message
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageSpec
+      default: {}
+    - name: status
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageStatus
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.MessageAcknowledgement
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageAcknowledgementSpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.MessageAcknowledgementSpec
+  map:
+    fields:
+    - name: acknowledgedBy
+      type:
+        scalar: string
+      default: ""
+    - name: comment
+      type:
+        scalar: string
+    - name: messageRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageReference
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.MessageDelivery
+  map:
+    fields:
+    - name: attempts
+      type:
+        scalar: numeric
+    - name: deliveredAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: error
+      type:
+        scalar: string
+    - name: sink
+      type:
+        scalar: string
+      default: ""
+    - name: state
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.MessageReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: uid
+      type:
+        scalar: string
+- name: com.github.yasker.example-crd.apis.message.v1.MessageSchedule
+  map:
+    fields:
+    - name: cron
+      type:
+        scalar: string
+      default: ""
+    - name: historyLimit
+      type:
+        scalar: numeric
+    - name: timeZone
+      type:
+        scalar: string
+- name: com.github.yasker.example-crd.apis.message.v1.MessageSpec
+  map:
+    fields:
+    - name: acknowledgement
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.AcknowledgementPolicy
+    - name: channelRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.ChannelReference
+    - name: context
+      type:
+        scalar: string
+    - name: deliverAt
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: escalationPolicyRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.EscalationPolicyReference
+    - name: idempotencyKey
+      type:
+        scalar: string
+    - name: orderingKey
+      type:
+        scalar: string
+    - name: schedule
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageSchedule
+    - name: templateRef
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.TemplateReference
+    - name: topic
+      type:
+        scalar: string
+    - name: urgent
+      type:
+        scalar: boolean
+      default: false
+- name: com.github.yasker.example-crd.apis.message.v1.MessageStatus
+  map:
+    fields:
+    - name: acknowledgement
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.AcknowledgementStatus
+    - name: conditions
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Condition
+          elementRelationship: associative
+          keys:
+          - type
+    - name: deliveries
+      type:
+        list:
+          elementType:
+            namedType: com.github.yasker.example-crd.apis.message.v1.MessageDelivery
+          elementRelationship: associative
+          keys:
+          - sink
+    - name: droppedAttempts
+      type:
+        scalar: numeric
+    - name: duplicateOf
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageReference
+    - name: escalation
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.EscalationStatus
+    - name: failedAttempts
+      type:
+        list:
+          elementType:
+            namedType: com.github.yasker.example-crd.apis.message.v1.DeliveryAttempt
+          elementRelationship: atomic
+    - name: lastScheduleTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: nextScheduleTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: rendered
+      type:
+        scalar: string
+    - name: state
+      type:
+        scalar: string
+    - name: subscribers
+      type:
+        scalar: numeric
+- name: com.github.yasker.example-crd.apis.message.v1.MessageTemplate
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.MessageTemplateSpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.MessageTemplateSpec
+  map:
+    fields:
+    - name: body
+      type:
+        scalar: string
+      default: ""
+    - name: parameters
+      type:
+        list:
+          elementType:
+            namedType: com.github.yasker.example-crd.apis.message.v1.TemplateParameter
+          elementRelationship: associative
+          keys:
+          - name
+- name: com.github.yasker.example-crd.apis.message.v1.RateLimit
+  map:
+    fields:
+    - name: burst
+      type:
+        scalar: numeric
+    - name: limit
+      type:
+        scalar: numeric
+      default: 0
+    - name: period
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+- name: com.github.yasker.example-crd.apis.message.v1.Recipient
+  map:
+    fields:
+    - name: settings
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: sink
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.Subscription
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+      default: {}
+    - name: spec
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.SubscriptionSpec
+      default: {}
+- name: com.github.yasker.example-crd.apis.message.v1.SubscriptionFilter
+  map:
+    fields:
+    - name: selector
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+    - name: urgent
+      type:
+        scalar: boolean
+- name: com.github.yasker.example-crd.apis.message.v1.SubscriptionSpec
+  map:
+    fields:
+    - name: filter
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.SubscriptionFilter
+    - name: recipient
+      type:
+        namedType: com.github.yasker.example-crd.apis.message.v1.Recipient
+      default: {}
+    - name: topic
+      type:
+        scalar: string
+      default: ""
+- name: com.github.yasker.example-crd.apis.message.v1.TemplateParameter
+  map:
+    fields:
+    - name: default
+      type:
+        scalar: string
+    - name: description
+      type:
+        scalar: string
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: required
+      type:
+        scalar: boolean
+- name: com.github.yasker.example-crd.apis.message.v1.TemplateReference
+  map:
+    fields:
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: parameters
+      type:
+        map:
+          elementType:
+            scalar: string
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.Condition
+  map:
+    fields:
+    - name: lastTransitionTime
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: message
+      type:
+        scalar: string
+      default: ""
+    - name: observedGeneration
+      type:
+        scalar: numeric
+    - name: reason
+      type:
+        scalar: string
+      default: ""
+    - name: status
+      type:
+        scalar: string
+      default: ""
+    - name: type
+      type:
+        scalar: string
+      default: ""
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.Duration
+  scalar: string
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.FieldsV1
+  map:
+    elementType:
+      scalar: untyped
+      list:
+        elementType:
+          namedType: __untyped_atomic_
+        elementRelationship: atomic
+      map:
+        elementType:
+          namedType: __untyped_deduced_
+        elementRelationship: separable
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector
+  map:
+    fields:
+    - name: matchExpressions
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorRequirement
+          elementRelationship: atomic
+    - name: matchLabels
+      type:
+        map:
+          elementType:
+            scalar: string
+    elementRelationship: atomic
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelectorRequirement
+  map:
+    fields:
+    - name: key
+      type:
+        scalar: string
+      default: ""
+    - name: operator
+      type:
+        scalar: string
+      default: ""
+    - name: values
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: atomic
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.ManagedFieldsEntry
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: fieldsType
+      type:
+        scalar: string
+    - name: fieldsV1
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.FieldsV1
+    - name: manager
+      type:
+        scalar: string
+    - name: operation
+      type:
+        scalar: string
+    - name: subresource
+      type:
+        scalar: string
+    - name: time
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta
+  map:
+    fields:
+    - name: annotations
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: creationTimestamp
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: deletionGracePeriodSeconds
+      type:
+        scalar: numeric
+    - name: deletionTimestamp
+      type:
+        namedType: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+    - name: finalizers
+      type:
+        list:
+          elementType:
+            scalar: string
+          elementRelationship: associative
+    - name: generateName
+      type:
+        scalar: string
+    - name: generation
+      type:
+        scalar: numeric
+    - name: labels
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: managedFields
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.apimachinery.pkg.apis.meta.v1.ManagedFieldsEntry
+          elementRelationship: atomic
+    - name: name
+      type:
+        scalar: string
+    - name: namespace
+      type:
+        scalar: string
+    - name: ownerReferences
+      type:
+        list:
+          elementType:
+            namedType: io.k8s.apimachinery.pkg.apis.meta.v1.OwnerReference
+          elementRelationship: associative
+          keys:
+          - uid
+    - name: resourceVersion
+      type:
+        scalar: string
+    - name: selfLink
+      type:
+        scalar: string
+    - name: uid
+      type:
+        scalar: string
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.OwnerReference
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+      default: ""
+    - name: blockOwnerDeletion
+      type:
+        scalar: boolean
+    - name: controller
+      type:
+        scalar: boolean
+    - name: kind
+      type:
+        scalar: string
+      default: ""
+    - name: name
+      type:
+        scalar: string
+      default: ""
+    - name: uid
+      type:
+        scalar: string
+      default: ""
+    elementRelationship: atomic
+- name: io.k8s.apimachinery.pkg.apis.meta.v1.Time
+  scalar: untyped
+- name: __untyped_atomic_
+  scalar: untyped
+  list:
+    elementType:
+      namedType: __untyped_atomic_
+    elementRelationship: atomic
+  map:
+    elementType:
+      namedType: __untyped_atomic_
+    elementRelationship: atomic
+- name: __untyped_deduced_
+  scalar: untyped
+  list:
+    elementType:
+      namedType: __untyped_atomic_
+    elementRelationship: atomic
+  map:
+    elementType:
+      namedType: __untyped_deduced_
+    elementRelationship: separable
+`)
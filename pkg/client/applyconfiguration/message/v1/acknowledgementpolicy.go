@@ -0,0 +1,52 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AcknowledgementPolicyApplyConfiguration represents a declarative configuration of the AcknowledgementPolicy type for use
+// with apply.
+type AcknowledgementPolicyApplyConfiguration struct {
+	Timeout              *metav1.Duration                    `json:"timeout,omitempty"`
+	EscalationChannelRef *ChannelReferenceApplyConfiguration `json:"escalationChannelRef,omitempty"`
+}
+
+// AcknowledgementPolicyApplyConfiguration constructs a declarative configuration of the AcknowledgementPolicy type for use with
+// apply.
+func AcknowledgementPolicy() *AcknowledgementPolicyApplyConfiguration {
+	return &AcknowledgementPolicyApplyConfiguration{}
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *AcknowledgementPolicyApplyConfiguration) WithTimeout(value metav1.Duration) *AcknowledgementPolicyApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithEscalationChannelRef sets the EscalationChannelRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EscalationChannelRef field is set to the value of the last call.
+func (b *AcknowledgementPolicyApplyConfiguration) WithEscalationChannelRef(value *ChannelReferenceApplyConfiguration) *AcknowledgementPolicyApplyConfiguration {
+	b.EscalationChannelRef = value
+	return b
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AcknowledgementStatusApplyConfiguration represents a declarative configuration of the AcknowledgementStatus type for use
+// with apply.
+type AcknowledgementStatusApplyConfiguration struct {
+	State          *messagev1.AcknowledgementState `json:"state,omitempty"`
+	Deadline       *metav1.Time                    `json:"deadline,omitempty"`
+	AcknowledgedBy *string                         `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt *metav1.Time                    `json:"acknowledgedAt,omitempty"`
+	EscalatedAt    *metav1.Time                    `json:"escalatedAt,omitempty"`
+}
+
+// AcknowledgementStatusApplyConfiguration constructs a declarative configuration of the AcknowledgementStatus type for use with
+// apply.
+func AcknowledgementStatus() *AcknowledgementStatusApplyConfiguration {
+	return &AcknowledgementStatusApplyConfiguration{}
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *AcknowledgementStatusApplyConfiguration) WithState(value messagev1.AcknowledgementState) *AcknowledgementStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithDeadline sets the Deadline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deadline field is set to the value of the last call.
+func (b *AcknowledgementStatusApplyConfiguration) WithDeadline(value metav1.Time) *AcknowledgementStatusApplyConfiguration {
+	b.Deadline = &value
+	return b
+}
+
+// WithAcknowledgedBy sets the AcknowledgedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AcknowledgedBy field is set to the value of the last call.
+func (b *AcknowledgementStatusApplyConfiguration) WithAcknowledgedBy(value string) *AcknowledgementStatusApplyConfiguration {
+	b.AcknowledgedBy = &value
+	return b
+}
+
+// WithAcknowledgedAt sets the AcknowledgedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AcknowledgedAt field is set to the value of the last call.
+func (b *AcknowledgementStatusApplyConfiguration) WithAcknowledgedAt(value metav1.Time) *AcknowledgementStatusApplyConfiguration {
+	b.AcknowledgedAt = &value
+	return b
+}
+
+// WithEscalatedAt sets the EscalatedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EscalatedAt field is set to the value of the last call.
+func (b *AcknowledgementStatusApplyConfiguration) WithEscalatedAt(value metav1.Time) *AcknowledgementStatusApplyConfiguration {
+	b.EscalatedAt = &value
+	return b
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BroadcastChannelSpecApplyConfiguration represents a declarative configuration of the BroadcastChannelSpec type for use
+// with apply.
+type BroadcastChannelSpecApplyConfiguration struct {
+	Sink              *messagev1.SinkType          `json:"sink,omitempty"`
+	Settings          map[string]string            `json:"settings,omitempty"`
+	RateLimit         *RateLimitApplyConfiguration `json:"rateLimit,omitempty"`
+	DedupWindow       *metav1.Duration             `json:"dedupWindow,omitempty"`
+	AllowedNamespaces []string                     `json:"allowedNamespaces,omitempty"`
+}
+
+// BroadcastChannelSpecApplyConfiguration constructs a declarative configuration of the BroadcastChannelSpec type for use with
+// apply.
+func BroadcastChannelSpec() *BroadcastChannelSpecApplyConfiguration {
+	return &BroadcastChannelSpecApplyConfiguration{}
+}
+
+// WithSink sets the Sink field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sink field is set to the value of the last call.
+func (b *BroadcastChannelSpecApplyConfiguration) WithSink(value messagev1.SinkType) *BroadcastChannelSpecApplyConfiguration {
+	b.Sink = &value
+	return b
+}
+
+// WithSettings puts the entries into the Settings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Settings field,
+// overwriting an existing map entries in Settings field with the same key.
+func (b *BroadcastChannelSpecApplyConfiguration) WithSettings(entries map[string]string) *BroadcastChannelSpecApplyConfiguration {
+	if b.Settings == nil && len(entries) > 0 {
+		b.Settings = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Settings[k] = v
+	}
+	return b
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *BroadcastChannelSpecApplyConfiguration) WithRateLimit(value *RateLimitApplyConfiguration) *BroadcastChannelSpecApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
+
+// WithDedupWindow sets the DedupWindow field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DedupWindow field is set to the value of the last call.
+func (b *BroadcastChannelSpecApplyConfiguration) WithDedupWindow(value metav1.Duration) *BroadcastChannelSpecApplyConfiguration {
+	b.DedupWindow = &value
+	return b
+}
+
+// WithAllowedNamespaces adds the given value to the AllowedNamespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AllowedNamespaces field.
+func (b *BroadcastChannelSpecApplyConfiguration) WithAllowedNamespaces(values ...string) *BroadcastChannelSpecApplyConfiguration {
+	for i := range values {
+		b.AllowedNamespaces = append(b.AllowedNamespaces, values[i])
+	}
+	return b
+}
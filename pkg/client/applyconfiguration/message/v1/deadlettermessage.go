@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// DeadLetterMessageApplyConfiguration represents a declarative configuration of the DeadLetterMessage type for use
+// with apply.
+type DeadLetterMessageApplyConfiguration struct {
+	Namespace         *string                        `json:"namespace,omitempty"`
+	Name              *string                        `json:"name,omitempty"`
+	UID               *types.UID                     `json:"uid,omitempty"`
+	Labels            map[string]string              `json:"labels,omitempty"`
+	CreationTimestamp *metav1.Time                   `json:"creationTimestamp,omitempty"`
+	Spec              *MessageSpecApplyConfiguration `json:"spec,omitempty"`
+}
+
+// DeadLetterMessageApplyConfiguration constructs a declarative configuration of the DeadLetterMessage type for use with
+// apply.
+func DeadLetterMessage() *DeadLetterMessageApplyConfiguration {
+	return &DeadLetterMessageApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *DeadLetterMessageApplyConfiguration) WithNamespace(value string) *DeadLetterMessageApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *DeadLetterMessageApplyConfiguration) WithName(value string) *DeadLetterMessageApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *DeadLetterMessageApplyConfiguration) WithUID(value types.UID) *DeadLetterMessageApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *DeadLetterMessageApplyConfiguration) WithLabels(entries map[string]string) *DeadLetterMessageApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithCreationTimestamp sets the CreationTimestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreationTimestamp field is set to the value of the last call.
+func (b *DeadLetterMessageApplyConfiguration) WithCreationTimestamp(value metav1.Time) *DeadLetterMessageApplyConfiguration {
+	b.CreationTimestamp = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *DeadLetterMessageApplyConfiguration) WithSpec(value *MessageSpecApplyConfiguration) *DeadLetterMessageApplyConfiguration {
+	b.Spec = value
+	return b
+}
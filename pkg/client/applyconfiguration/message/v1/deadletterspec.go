@@ -0,0 +1,75 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeadLetterSpecApplyConfiguration represents a declarative configuration of the DeadLetterSpec type for use
+// with apply.
+type DeadLetterSpecApplyConfiguration struct {
+	Message         *DeadLetterMessageApplyConfiguration `json:"message,omitempty"`
+	Attempts        []DeliveryAttemptApplyConfiguration  `json:"attempts,omitempty"`
+	DroppedAttempts *int32                               `json:"droppedAttempts,omitempty"`
+	FailedAt        *metav1.Time                         `json:"failedAt,omitempty"`
+}
+
+// DeadLetterSpecApplyConfiguration constructs a declarative configuration of the DeadLetterSpec type for use with
+// apply.
+func DeadLetterSpec() *DeadLetterSpecApplyConfiguration {
+	return &DeadLetterSpecApplyConfiguration{}
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DeadLetterSpecApplyConfiguration) WithMessage(value *DeadLetterMessageApplyConfiguration) *DeadLetterSpecApplyConfiguration {
+	b.Message = value
+	return b
+}
+
+// WithAttempts adds the given value to the Attempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Attempts field.
+func (b *DeadLetterSpecApplyConfiguration) WithAttempts(values ...*DeliveryAttemptApplyConfiguration) *DeadLetterSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAttempts")
+		}
+		b.Attempts = append(b.Attempts, *values[i])
+	}
+	return b
+}
+
+// WithDroppedAttempts sets the DroppedAttempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DroppedAttempts field is set to the value of the last call.
+func (b *DeadLetterSpecApplyConfiguration) WithDroppedAttempts(value int32) *DeadLetterSpecApplyConfiguration {
+	b.DroppedAttempts = &value
+	return b
+}
+
+// WithFailedAt sets the FailedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedAt field is set to the value of the last call.
+func (b *DeadLetterSpecApplyConfiguration) WithFailedAt(value metav1.Time) *DeadLetterSpecApplyConfiguration {
+	b.FailedAt = &value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeliveryAttemptApplyConfiguration represents a declarative configuration of the DeliveryAttempt type for use
+// with apply.
+type DeliveryAttemptApplyConfiguration struct {
+	Sink  *string      `json:"sink,omitempty"`
+	Error *string      `json:"error,omitempty"`
+	Time  *metav1.Time `json:"time,omitempty"`
+}
+
+// DeliveryAttemptApplyConfiguration constructs a declarative configuration of the DeliveryAttempt type for use with
+// apply.
+func DeliveryAttempt() *DeliveryAttemptApplyConfiguration {
+	return &DeliveryAttemptApplyConfiguration{}
+}
+
+// WithSink sets the Sink field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sink field is set to the value of the last call.
+func (b *DeliveryAttemptApplyConfiguration) WithSink(value string) *DeliveryAttemptApplyConfiguration {
+	b.Sink = &value
+	return b
+}
+
+// WithError sets the Error field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Error field is set to the value of the last call.
+func (b *DeliveryAttemptApplyConfiguration) WithError(value string) *DeliveryAttemptApplyConfiguration {
+	b.Error = &value
+	return b
+}
+
+// WithTime sets the Time field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Time field is set to the value of the last call.
+func (b *DeliveryAttemptApplyConfiguration) WithTime(value metav1.Time) *DeliveryAttemptApplyConfiguration {
+	b.Time = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EscalationPolicySpecApplyConfiguration represents a declarative configuration of the EscalationPolicySpec type for use
+// with apply.
+type EscalationPolicySpecApplyConfiguration struct {
+	UndeliveredAfter *metav1.Duration                   `json:"undeliveredAfter,omitempty"`
+	Steps            []EscalationStepApplyConfiguration `json:"steps,omitempty"`
+}
+
+// EscalationPolicySpecApplyConfiguration constructs a declarative configuration of the EscalationPolicySpec type for use with
+// apply.
+func EscalationPolicySpec() *EscalationPolicySpecApplyConfiguration {
+	return &EscalationPolicySpecApplyConfiguration{}
+}
+
+// WithUndeliveredAfter sets the UndeliveredAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UndeliveredAfter field is set to the value of the last call.
+func (b *EscalationPolicySpecApplyConfiguration) WithUndeliveredAfter(value metav1.Duration) *EscalationPolicySpecApplyConfiguration {
+	b.UndeliveredAfter = &value
+	return b
+}
+
+// WithSteps adds the given value to the Steps field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Steps field.
+func (b *EscalationPolicySpecApplyConfiguration) WithSteps(values ...*EscalationStepApplyConfiguration) *EscalationPolicySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSteps")
+		}
+		b.Steps = append(b.Steps, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EscalationStatusApplyConfiguration represents a declarative configuration of the EscalationStatus type for use
+// with apply.
+type EscalationStatusApplyConfiguration struct {
+	Policy          *string                     `json:"policy,omitempty"`
+	Reason          *messagev1.EscalationReason `json:"reason,omitempty"`
+	State           *messagev1.EscalationState  `json:"state,omitempty"`
+	Step            *int32                      `json:"step,omitempty"`
+	Repeats         *int32                      `json:"repeats,omitempty"`
+	NextAt          *metav1.Time                `json:"nextAt,omitempty"`
+	LastEscalatedAt *metav1.Time                `json:"lastEscalatedAt,omitempty"`
+}
+
+// EscalationStatusApplyConfiguration constructs a declarative configuration of the EscalationStatus type for use with
+// apply.
+func EscalationStatus() *EscalationStatusApplyConfiguration {
+	return &EscalationStatusApplyConfiguration{}
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithPolicy(value string) *EscalationStatusApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithReason(value messagev1.EscalationReason) *EscalationStatusApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithState(value messagev1.EscalationState) *EscalationStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithStep sets the Step field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Step field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithStep(value int32) *EscalationStatusApplyConfiguration {
+	b.Step = &value
+	return b
+}
+
+// WithRepeats sets the Repeats field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Repeats field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithRepeats(value int32) *EscalationStatusApplyConfiguration {
+	b.Repeats = &value
+	return b
+}
+
+// WithNextAt sets the NextAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextAt field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithNextAt(value metav1.Time) *EscalationStatusApplyConfiguration {
+	b.NextAt = &value
+	return b
+}
+
+// WithLastEscalatedAt sets the LastEscalatedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastEscalatedAt field is set to the value of the last call.
+func (b *EscalationStatusApplyConfiguration) WithLastEscalatedAt(value metav1.Time) *EscalationStatusApplyConfiguration {
+	b.LastEscalatedAt = &value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EscalationStepApplyConfiguration represents a declarative configuration of the EscalationStep type for use
+// with apply.
+type EscalationStepApplyConfiguration struct {
+	Delay      *metav1.Duration                    `json:"delay,omitempty"`
+	ChannelRef *ChannelReferenceApplyConfiguration `json:"channelRef,omitempty"`
+	Repeat     *int32                              `json:"repeat,omitempty"`
+}
+
+// EscalationStepApplyConfiguration constructs a declarative configuration of the EscalationStep type for use with
+// apply.
+func EscalationStep() *EscalationStepApplyConfiguration {
+	return &EscalationStepApplyConfiguration{}
+}
+
+// WithDelay sets the Delay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Delay field is set to the value of the last call.
+func (b *EscalationStepApplyConfiguration) WithDelay(value metav1.Duration) *EscalationStepApplyConfiguration {
+	b.Delay = &value
+	return b
+}
+
+// WithChannelRef sets the ChannelRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChannelRef field is set to the value of the last call.
+func (b *EscalationStepApplyConfiguration) WithChannelRef(value *ChannelReferenceApplyConfiguration) *EscalationStepApplyConfiguration {
+	b.ChannelRef = value
+	return b
+}
+
+// WithRepeat sets the Repeat field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Repeat field is set to the value of the last call.
+func (b *EscalationStepApplyConfiguration) WithRepeat(value int32) *EscalationStepApplyConfiguration {
+	b.Repeat = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// MessageAcknowledgementSpecApplyConfiguration represents a declarative configuration of the MessageAcknowledgementSpec type for use
+// with apply.
+type MessageAcknowledgementSpecApplyConfiguration struct {
+	MessageRef     *MessageReferenceApplyConfiguration `json:"messageRef,omitempty"`
+	AcknowledgedBy *string                             `json:"acknowledgedBy,omitempty"`
+	Comment        *string                             `json:"comment,omitempty"`
+}
+
+// MessageAcknowledgementSpecApplyConfiguration constructs a declarative configuration of the MessageAcknowledgementSpec type for use with
+// apply.
+func MessageAcknowledgementSpec() *MessageAcknowledgementSpecApplyConfiguration {
+	return &MessageAcknowledgementSpecApplyConfiguration{}
+}
+
+// WithMessageRef sets the MessageRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MessageRef field is set to the value of the last call.
+func (b *MessageAcknowledgementSpecApplyConfiguration) WithMessageRef(value *MessageReferenceApplyConfiguration) *MessageAcknowledgementSpecApplyConfiguration {
+	b.MessageRef = value
+	return b
+}
+
+// WithAcknowledgedBy sets the AcknowledgedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AcknowledgedBy field is set to the value of the last call.
+func (b *MessageAcknowledgementSpecApplyConfiguration) WithAcknowledgedBy(value string) *MessageAcknowledgementSpecApplyConfiguration {
+	b.AcknowledgedBy = &value
+	return b
+}
+
+// WithComment sets the Comment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Comment field is set to the value of the last call.
+func (b *MessageAcknowledgementSpecApplyConfiguration) WithComment(value string) *MessageAcknowledgementSpecApplyConfiguration {
+	b.Comment = &value
+	return b
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MessageDeliveryApplyConfiguration represents a declarative configuration of the MessageDelivery type for use
+// with apply.
+type MessageDeliveryApplyConfiguration struct {
+	Sink        *string                  `json:"sink,omitempty"`
+	State       *messagev1.DeliveryState `json:"state,omitempty"`
+	Error       *string                  `json:"error,omitempty"`
+	DeliveredAt *metav1.Time             `json:"deliveredAt,omitempty"`
+	Attempts    *int32                   `json:"attempts,omitempty"`
+}
+
+// MessageDeliveryApplyConfiguration constructs a declarative configuration of the MessageDelivery type for use with
+// apply.
+func MessageDelivery() *MessageDeliveryApplyConfiguration {
+	return &MessageDeliveryApplyConfiguration{}
+}
+
+// WithSink sets the Sink field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sink field is set to the value of the last call.
+func (b *MessageDeliveryApplyConfiguration) WithSink(value string) *MessageDeliveryApplyConfiguration {
+	b.Sink = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *MessageDeliveryApplyConfiguration) WithState(value messagev1.DeliveryState) *MessageDeliveryApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithError sets the Error field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Error field is set to the value of the last call.
+func (b *MessageDeliveryApplyConfiguration) WithError(value string) *MessageDeliveryApplyConfiguration {
+	b.Error = &value
+	return b
+}
+
+// WithDeliveredAt sets the DeliveredAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeliveredAt field is set to the value of the last call.
+func (b *MessageDeliveryApplyConfiguration) WithDeliveredAt(value metav1.Time) *MessageDeliveryApplyConfiguration {
+	b.DeliveredAt = &value
+	return b
+}
+
+// WithAttempts sets the Attempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Attempts field is set to the value of the last call.
+func (b *MessageDeliveryApplyConfiguration) WithAttempts(value int32) *MessageDeliveryApplyConfiguration {
+	b.Attempts = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// MessageScheduleApplyConfiguration represents a declarative configuration of the MessageSchedule type for use
+// with apply.
+type MessageScheduleApplyConfiguration struct {
+	Cron         *string `json:"cron,omitempty"`
+	TimeZone     *string `json:"timeZone,omitempty"`
+	HistoryLimit *int32  `json:"historyLimit,omitempty"`
+}
+
+// MessageScheduleApplyConfiguration constructs a declarative configuration of the MessageSchedule type for use with
+// apply.
+func MessageSchedule() *MessageScheduleApplyConfiguration {
+	return &MessageScheduleApplyConfiguration{}
+}
+
+// WithCron sets the Cron field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cron field is set to the value of the last call.
+func (b *MessageScheduleApplyConfiguration) WithCron(value string) *MessageScheduleApplyConfiguration {
+	b.Cron = &value
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *MessageScheduleApplyConfiguration) WithTimeZone(value string) *MessageScheduleApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
+
+// WithHistoryLimit sets the HistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HistoryLimit field is set to the value of the last call.
+func (b *MessageScheduleApplyConfiguration) WithHistoryLimit(value int32) *MessageScheduleApplyConfiguration {
+	b.HistoryLimit = &value
+	return b
+}
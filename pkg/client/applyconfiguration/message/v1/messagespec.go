@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MessageSpecApplyConfiguration represents a declarative configuration of the MessageSpec type for use
+// with apply.
+type MessageSpecApplyConfiguration struct {
+	Context             *string                                      `json:"context,omitempty"`
+	TemplateRef         *TemplateReferenceApplyConfiguration         `json:"templateRef,omitempty"`
+	Urgent              *bool                                        `json:"urgent,omitempty"`
+	ChannelRef          *ChannelReferenceApplyConfiguration          `json:"channelRef,omitempty"`
+	Topic               *string                                      `json:"topic,omitempty"`
+	Acknowledgement     *AcknowledgementPolicyApplyConfiguration     `json:"acknowledgement,omitempty"`
+	EscalationPolicyRef *EscalationPolicyReferenceApplyConfiguration `json:"escalationPolicyRef,omitempty"`
+	DeliverAt           *metav1.Time                                 `json:"deliverAt,omitempty"`
+	IdempotencyKey      *string                                      `json:"idempotencyKey,omitempty"`
+	OrderingKey         *string                                      `json:"orderingKey,omitempty"`
+	Schedule            *MessageScheduleApplyConfiguration           `json:"schedule,omitempty"`
+}
+
+// MessageSpecApplyConfiguration constructs a declarative configuration of the MessageSpec type for use with
+// apply.
+func MessageSpec() *MessageSpecApplyConfiguration {
+	return &MessageSpecApplyConfiguration{}
+}
+
+// WithContext sets the Context field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Context field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithContext(value string) *MessageSpecApplyConfiguration {
+	b.Context = &value
+	return b
+}
+
+// WithTemplateRef sets the TemplateRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TemplateRef field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithTemplateRef(value *TemplateReferenceApplyConfiguration) *MessageSpecApplyConfiguration {
+	b.TemplateRef = value
+	return b
+}
+
+// WithUrgent sets the Urgent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Urgent field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithUrgent(value bool) *MessageSpecApplyConfiguration {
+	b.Urgent = &value
+	return b
+}
+
+// WithChannelRef sets the ChannelRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChannelRef field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithChannelRef(value *ChannelReferenceApplyConfiguration) *MessageSpecApplyConfiguration {
+	b.ChannelRef = value
+	return b
+}
+
+// WithTopic sets the Topic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Topic field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithTopic(value string) *MessageSpecApplyConfiguration {
+	b.Topic = &value
+	return b
+}
+
+// WithAcknowledgement sets the Acknowledgement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Acknowledgement field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithAcknowledgement(value *AcknowledgementPolicyApplyConfiguration) *MessageSpecApplyConfiguration {
+	b.Acknowledgement = value
+	return b
+}
+
+// WithEscalationPolicyRef sets the EscalationPolicyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EscalationPolicyRef field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithEscalationPolicyRef(value *EscalationPolicyReferenceApplyConfiguration) *MessageSpecApplyConfiguration {
+	b.EscalationPolicyRef = value
+	return b
+}
+
+// WithDeliverAt sets the DeliverAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeliverAt field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithDeliverAt(value metav1.Time) *MessageSpecApplyConfiguration {
+	b.DeliverAt = &value
+	return b
+}
+
+// WithIdempotencyKey sets the IdempotencyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdempotencyKey field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithIdempotencyKey(value string) *MessageSpecApplyConfiguration {
+	b.IdempotencyKey = &value
+	return b
+}
+
+// WithOrderingKey sets the OrderingKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrderingKey field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithOrderingKey(value string) *MessageSpecApplyConfiguration {
+	b.OrderingKey = &value
+	return b
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *MessageSpecApplyConfiguration) WithSchedule(value *MessageScheduleApplyConfiguration) *MessageSpecApplyConfiguration {
+	b.Schedule = value
+	return b
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applyconfigurationsmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// MessageStatusApplyConfiguration represents a declarative configuration of the MessageStatus type for use
+// with apply.
+type MessageStatusApplyConfiguration struct {
+	State            *messagev1.MessageState                                 `json:"state,omitempty"`
+	Subscribers      *int32                                                  `json:"subscribers,omitempty"`
+	Rendered         *string                                                 `json:"rendered,omitempty"`
+	Deliveries       []MessageDeliveryApplyConfiguration                     `json:"deliveries,omitempty"`
+	Acknowledgement  *AcknowledgementStatusApplyConfiguration                `json:"acknowledgement,omitempty"`
+	FailedAttempts   []DeliveryAttemptApplyConfiguration                     `json:"failedAttempts,omitempty"`
+	DroppedAttempts  *int32                                                  `json:"droppedAttempts,omitempty"`
+	DuplicateOf      *MessageReferenceApplyConfiguration                     `json:"duplicateOf,omitempty"`
+	Escalation       *EscalationStatusApplyConfiguration                     `json:"escalation,omitempty"`
+	LastScheduleTime *metav1.Time                                            `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime *metav1.Time                                            `json:"nextScheduleTime,omitempty"`
+	Conditions       []applyconfigurationsmetav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+}
+
+// MessageStatusApplyConfiguration constructs a declarative configuration of the MessageStatus type for use with
+// apply.
+func MessageStatus() *MessageStatusApplyConfiguration {
+	return &MessageStatusApplyConfiguration{}
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithState(value messagev1.MessageState) *MessageStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithSubscribers sets the Subscribers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subscribers field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithSubscribers(value int32) *MessageStatusApplyConfiguration {
+	b.Subscribers = &value
+	return b
+}
+
+// WithRendered sets the Rendered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rendered field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithRendered(value string) *MessageStatusApplyConfiguration {
+	b.Rendered = &value
+	return b
+}
+
+// WithDeliveries adds the given value to the Deliveries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Deliveries field.
+func (b *MessageStatusApplyConfiguration) WithDeliveries(values ...*MessageDeliveryApplyConfiguration) *MessageStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDeliveries")
+		}
+		b.Deliveries = append(b.Deliveries, *values[i])
+	}
+	return b
+}
+
+// WithAcknowledgement sets the Acknowledgement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Acknowledgement field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithAcknowledgement(value *AcknowledgementStatusApplyConfiguration) *MessageStatusApplyConfiguration {
+	b.Acknowledgement = value
+	return b
+}
+
+// WithFailedAttempts adds the given value to the FailedAttempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedAttempts field.
+func (b *MessageStatusApplyConfiguration) WithFailedAttempts(values ...*DeliveryAttemptApplyConfiguration) *MessageStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailedAttempts")
+		}
+		b.FailedAttempts = append(b.FailedAttempts, *values[i])
+	}
+	return b
+}
+
+// WithDroppedAttempts sets the DroppedAttempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DroppedAttempts field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithDroppedAttempts(value int32) *MessageStatusApplyConfiguration {
+	b.DroppedAttempts = &value
+	return b
+}
+
+// WithDuplicateOf sets the DuplicateOf field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DuplicateOf field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithDuplicateOf(value *MessageReferenceApplyConfiguration) *MessageStatusApplyConfiguration {
+	b.DuplicateOf = value
+	return b
+}
+
+// WithEscalation sets the Escalation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Escalation field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithEscalation(value *EscalationStatusApplyConfiguration) *MessageStatusApplyConfiguration {
+	b.Escalation = value
+	return b
+}
+
+// WithLastScheduleTime sets the LastScheduleTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScheduleTime field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithLastScheduleTime(value metav1.Time) *MessageStatusApplyConfiguration {
+	b.LastScheduleTime = &value
+	return b
+}
+
+// WithNextScheduleTime sets the NextScheduleTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextScheduleTime field is set to the value of the last call.
+func (b *MessageStatusApplyConfiguration) WithNextScheduleTime(value metav1.Time) *MessageStatusApplyConfiguration {
+	b.NextScheduleTime = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *MessageStatusApplyConfiguration) WithConditions(values ...*applyconfigurationsmetav1.ConditionApplyConfiguration) *MessageStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RateLimitApplyConfiguration represents a declarative configuration of the RateLimit type for use
+// with apply.
+type RateLimitApplyConfiguration struct {
+	Limit  *int32           `json:"limit,omitempty"`
+	Period *metav1.Duration `json:"period,omitempty"`
+	Burst  *int32           `json:"burst,omitempty"`
+}
+
+// RateLimitApplyConfiguration constructs a declarative configuration of the RateLimit type for use with
+// apply.
+func RateLimit() *RateLimitApplyConfiguration {
+	return &RateLimitApplyConfiguration{}
+}
+
+// WithLimit sets the Limit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Limit field is set to the value of the last call.
+func (b *RateLimitApplyConfiguration) WithLimit(value int32) *RateLimitApplyConfiguration {
+	b.Limit = &value
+	return b
+}
+
+// WithPeriod sets the Period field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Period field is set to the value of the last call.
+func (b *RateLimitApplyConfiguration) WithPeriod(value metav1.Duration) *RateLimitApplyConfiguration {
+	b.Period = &value
+	return b
+}
+
+// WithBurst sets the Burst field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Burst field is set to the value of the last call.
+func (b *RateLimitApplyConfiguration) WithBurst(value int32) *RateLimitApplyConfiguration {
+	b.Burst = &value
+	return b
+}
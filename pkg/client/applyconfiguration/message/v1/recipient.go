@@ -0,0 +1,58 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// RecipientApplyConfiguration represents a declarative configuration of the Recipient type for use
+// with apply.
+type RecipientApplyConfiguration struct {
+	Sink     *messagev1.SinkType `json:"sink,omitempty"`
+	Settings map[string]string   `json:"settings,omitempty"`
+}
+
+// RecipientApplyConfiguration constructs a declarative configuration of the Recipient type for use with
+// apply.
+func Recipient() *RecipientApplyConfiguration {
+	return &RecipientApplyConfiguration{}
+}
+
+// WithSink sets the Sink field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sink field is set to the value of the last call.
+func (b *RecipientApplyConfiguration) WithSink(value messagev1.SinkType) *RecipientApplyConfiguration {
+	b.Sink = &value
+	return b
+}
+
+// WithSettings puts the entries into the Settings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Settings field,
+// overwriting an existing map entries in Settings field with the same key.
+func (b *RecipientApplyConfiguration) WithSettings(entries map[string]string) *RecipientApplyConfiguration {
+	if b.Settings == nil && len(entries) > 0 {
+		b.Settings = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Settings[k] = v
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// SubscriptionFilterApplyConfiguration represents a declarative configuration of the SubscriptionFilter type for use
+// with apply.
+type SubscriptionFilterApplyConfiguration struct {
+	Selector *metav1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+	Urgent   *bool                                   `json:"urgent,omitempty"`
+}
+
+// SubscriptionFilterApplyConfiguration constructs a declarative configuration of the SubscriptionFilter type for use with
+// apply.
+func SubscriptionFilter() *SubscriptionFilterApplyConfiguration {
+	return &SubscriptionFilterApplyConfiguration{}
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *SubscriptionFilterApplyConfiguration) WithSelector(value *metav1.LabelSelectorApplyConfiguration) *SubscriptionFilterApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithUrgent sets the Urgent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Urgent field is set to the value of the last call.
+func (b *SubscriptionFilterApplyConfiguration) WithUrgent(value bool) *SubscriptionFilterApplyConfiguration {
+	b.Urgent = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// SubscriptionSpecApplyConfiguration represents a declarative configuration of the SubscriptionSpec type for use
+// with apply.
+type SubscriptionSpecApplyConfiguration struct {
+	Topic     *string                               `json:"topic,omitempty"`
+	Recipient *RecipientApplyConfiguration          `json:"recipient,omitempty"`
+	Filter    *SubscriptionFilterApplyConfiguration `json:"filter,omitempty"`
+}
+
+// SubscriptionSpecApplyConfiguration constructs a declarative configuration of the SubscriptionSpec type for use with
+// apply.
+func SubscriptionSpec() *SubscriptionSpecApplyConfiguration {
+	return &SubscriptionSpecApplyConfiguration{}
+}
+
+// WithTopic sets the Topic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Topic field is set to the value of the last call.
+func (b *SubscriptionSpecApplyConfiguration) WithTopic(value string) *SubscriptionSpecApplyConfiguration {
+	b.Topic = &value
+	return b
+}
+
+// WithRecipient sets the Recipient field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Recipient field is set to the value of the last call.
+func (b *SubscriptionSpecApplyConfiguration) WithRecipient(value *RecipientApplyConfiguration) *SubscriptionSpecApplyConfiguration {
+	b.Recipient = value
+	return b
+}
+
+// WithFilter sets the Filter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Filter field is set to the value of the last call.
+func (b *SubscriptionSpecApplyConfiguration) WithFilter(value *SubscriptionFilterApplyConfiguration) *SubscriptionSpecApplyConfiguration {
+	b.Filter = value
+	return b
+}
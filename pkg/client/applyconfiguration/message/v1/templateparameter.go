@@ -0,0 +1,66 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// TemplateParameterApplyConfiguration represents a declarative configuration of the TemplateParameter type for use
+// with apply.
+type TemplateParameterApplyConfiguration struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Required    *bool   `json:"required,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+// TemplateParameterApplyConfiguration constructs a declarative configuration of the TemplateParameter type for use with
+// apply.
+func TemplateParameter() *TemplateParameterApplyConfiguration {
+	return &TemplateParameterApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *TemplateParameterApplyConfiguration) WithName(value string) *TemplateParameterApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDescription sets the Description field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Description field is set to the value of the last call.
+func (b *TemplateParameterApplyConfiguration) WithDescription(value string) *TemplateParameterApplyConfiguration {
+	b.Description = &value
+	return b
+}
+
+// WithRequired sets the Required field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Required field is set to the value of the last call.
+func (b *TemplateParameterApplyConfiguration) WithRequired(value bool) *TemplateParameterApplyConfiguration {
+	b.Required = &value
+	return b
+}
+
+// WithDefault sets the Default field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Default field is set to the value of the last call.
+func (b *TemplateParameterApplyConfiguration) WithDefault(value string) *TemplateParameterApplyConfiguration {
+	b.Default = &value
+	return b
+}
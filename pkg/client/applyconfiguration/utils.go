@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	internal "github.com/yasker/example-crd/pkg/client/applyconfiguration/internal"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=example.rancher.io, Version=v1
+	case v1.SchemeGroupVersion.WithKind("AcknowledgementPolicy"):
+		return &messagev1.AcknowledgementPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("AcknowledgementStatus"):
+		return &messagev1.AcknowledgementStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BroadcastChannel"):
+		return &messagev1.BroadcastChannelApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BroadcastChannelSpec"):
+		return &messagev1.BroadcastChannelSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ChannelReference"):
+		return &messagev1.ChannelReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DeadLetter"):
+		return &messagev1.DeadLetterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DeadLetterMessage"):
+		return &messagev1.DeadLetterMessageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DeadLetterSpec"):
+		return &messagev1.DeadLetterSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DeliveryAttempt"):
+		return &messagev1.DeliveryAttemptApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EscalationPolicy"):
+		return &messagev1.EscalationPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EscalationPolicyReference"):
+		return &messagev1.EscalationPolicyReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EscalationPolicySpec"):
+		return &messagev1.EscalationPolicySpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EscalationStatus"):
+		return &messagev1.EscalationStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EscalationStep"):
+		return &messagev1.EscalationStepApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Message"):
+		return &messagev1.MessageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageAcknowledgement"):
+		return &messagev1.MessageAcknowledgementApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageAcknowledgementSpec"):
+		return &messagev1.MessageAcknowledgementSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageDelivery"):
+		return &messagev1.MessageDeliveryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageReference"):
+		return &messagev1.MessageReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageSchedule"):
+		return &messagev1.MessageScheduleApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageSpec"):
+		return &messagev1.MessageSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageStatus"):
+		return &messagev1.MessageStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageTemplate"):
+		return &messagev1.MessageTemplateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MessageTemplateSpec"):
+		return &messagev1.MessageTemplateSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RateLimit"):
+		return &messagev1.RateLimitApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Recipient"):
+		return &messagev1.RecipientApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Subscription"):
+		return &messagev1.SubscriptionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SubscriptionFilter"):
+		return &messagev1.SubscriptionFilterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SubscriptionSpec"):
+		return &messagev1.SubscriptionSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TemplateParameter"):
+		return &messagev1.TemplateParameterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TemplateReference"):
+		return &messagev1.TemplateReferenceApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) managedfields.TypeConverter {
+	return managedfields.NewSchemeTypeConverter(scheme, internal.Parser())
+}
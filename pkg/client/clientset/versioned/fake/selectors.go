@@ -0,0 +1,214 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// selectableFields returns, per resource, the fields a field selector can
+// match an object on. Resources not listed only support metadata fields.
+var selectableFields = map[string]func(obj runtime.Object) fields.Set{
+	messagev1.MessageResourcePlural: func(obj runtime.Object) fields.Set {
+		return messagev1.MessageSelectableFields(obj.(*messagev1.Message))
+	},
+}
+
+// NewClientsetWithSelectors returns a clientset like NewClientset, whose
+// List and Watch calls also honor the label and field selectors of their
+// ListOptions the way the API server does. Watchers are fed from the object
+// tracker, so they see every change made through the clientset.
+func NewClientsetWithSelectors(objects ...runtime.Object) *Clientset {
+	cs := NewClientset(objects...)
+	cs.PrependReactor("list", "*", cs.listWithSelectors)
+	cs.PrependWatchReactor("*", cs.watchWithSelectors)
+	return cs
+}
+
+func (c *Clientset) listWithSelectors(action testing.Action) (bool, runtime.Object, error) {
+	restrictions := action.(testing.ListAction).GetListRestrictions()
+	_, list, err := testing.ObjectReaction(c.tracker)(action)
+	if err != nil {
+		return true, nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return true, nil, err
+	}
+	matched := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		if matches(action.GetResource().Resource, item, restrictions.Labels, restrictions.Fields) {
+			matched = append(matched, item)
+		}
+	}
+	if err := meta.SetList(list, matched); err != nil {
+		return true, nil, err
+	}
+	return true, list, nil
+}
+
+func (c *Clientset) watchWithSelectors(action testing.Action) (bool, watch.Interface, error) {
+	gvr := action.GetResource()
+	restrictions := action.(testing.WatchAction).GetWatchRestrictions()
+	match := func(obj runtime.Object) bool {
+		return matches(gvr.Resource, obj, restrictions.Labels, restrictions.Fields)
+	}
+
+	w, err := c.tracker.Watch(gvr, action.GetNamespace())
+	if err != nil {
+		return false, nil, err
+	}
+	// The objects that match when the watch starts, so that a later change
+	// making one stop matching is sent as its deletion.
+	matched := map[string]runtime.Object{}
+	if gvk, ok := kindFor(gvr); ok {
+		list, err := c.tracker.List(gvr, gvk, action.GetNamespace())
+		if err != nil {
+			w.Stop()
+			return false, nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			w.Stop()
+			return false, nil, err
+		}
+		for _, item := range items {
+			if match(item) {
+				matched[objectKey(item)] = item
+			}
+		}
+	}
+	return true, filterWatch(w, match, matched), nil
+}
+
+// filterWatch passes on the events of source about objects that match, the
+// way the API server sends them for a watch with selectors: a change making
+// an object stop matching is sent as its deletion, and one making it start
+// matching as its addition. matched holds the objects that match so far.
+func filterWatch(source watch.Interface, match func(runtime.Object) bool, matched map[string]runtime.Object) watch.Interface {
+	result := make(chan watch.Event)
+	w := watch.NewProxyWatcher(result)
+	go func() {
+		defer close(result)
+		defer source.Stop()
+		for {
+			var event watch.Event
+			select {
+			case e, ok := <-source.ResultChan():
+				if !ok {
+					return
+				}
+				event = e
+			case <-w.StopChan():
+				return
+			}
+
+			event, send := filterEvent(event, match, matched)
+			if !send {
+				continue
+			}
+			select {
+			case result <- event:
+			case <-w.StopChan():
+				return
+			}
+		}
+	}()
+	return w
+}
+
+func filterEvent(event watch.Event, match func(runtime.Object) bool, matched map[string]runtime.Object) (watch.Event, bool) {
+	if event.Type != watch.Added && event.Type != watch.Modified && event.Type != watch.Deleted {
+		return event, true
+	}
+	key := objectKey(event.Object)
+	last, ok := matched[key]
+	if event.Type != watch.Deleted && match(event.Object) {
+		matched[key] = event.Object
+		if !ok {
+			event.Type = watch.Added
+		}
+		return event, true
+	}
+	delete(matched, key)
+	if !ok || event.Type == watch.Deleted {
+		return event, ok
+	}
+	// Like the API server, send the object as it last matched, at the
+	// resource version of the change.
+	deleted := last.DeepCopyObject()
+	if accessor, err := meta.Accessor(deleted); err == nil {
+		if current, err := meta.Accessor(event.Object); err == nil {
+			accessor.SetResourceVersion(current.GetResourceVersion())
+		}
+	}
+	return watch.Event{Type: watch.Deleted, Object: deleted}, true
+}
+
+func objectKey(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetNamespace() + "/" + accessor.GetName()
+}
+
+// kindFor returns the kind of the objects of resource gvr.
+func kindFor(gvr schema.GroupVersionResource) (schema.GroupVersionKind, bool) {
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.GroupVersion() != gvr.GroupVersion() || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		if plural, _ := meta.UnsafeGuessKindToResource(gvk); plural == gvr {
+			return gvk, true
+		}
+	}
+	return schema.GroupVersionKind{}, false
+}
+
+func matches(resource string, obj runtime.Object, label labels.Selector, field fields.Selector) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	if label != nil && !label.Matches(labels.Set(accessor.GetLabels())) {
+		return false
+	}
+	if field == nil || field.Empty() {
+		return true
+	}
+
+	set := fields.Set{
+		"metadata.name":      accessor.GetName(),
+		"metadata.namespace": accessor.GetNamespace(),
+	}
+	if selectable, ok := selectableFields[resource]; ok {
+		set = selectable(obj)
+	}
+	return field.Matches(set)
+}
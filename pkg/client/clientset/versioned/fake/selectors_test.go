@@ -0,0 +1,144 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+func testMessage(name string, urgent bool, labels map[string]string) *messagev1.Message {
+	return &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, Labels: labels},
+		Spec:       messagev1.MessageSpec{Urgent: urgent},
+	}
+}
+
+func TestListWithSelectors(t *testing.T) {
+	cs := NewClientsetWithSelectors(
+		testMessage("disk-full", true, map[string]string{"app": "db"}),
+		testMessage("backup-done", false, map[string]string{"app": "db"}),
+		testMessage("deployed", false, map[string]string{"app": "web"}),
+	)
+
+	tests := []struct {
+		name string
+		opts metav1.ListOptions
+		want []string
+	}{
+		{name: "everything", want: []string{"backup-done", "deployed", "disk-full"}},
+		{name: "label selector", opts: metav1.ListOptions{LabelSelector: "app=db"}, want: []string{"backup-done", "disk-full"}},
+		{name: "field selector", opts: metav1.ListOptions{FieldSelector: "spec.urgent=true"}, want: []string{"disk-full"}},
+		{name: "both", opts: metav1.ListOptions{LabelSelector: "app=web", FieldSelector: "spec.urgent=true"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := cs.MessageV1().Messages("team-a").List(context.Background(), test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, message := range list.Items {
+				got = append(got, message.Name)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("got %q, want %q", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchWithSelectors(t *testing.T) {
+	ctx := context.Background()
+	// stale matches the selector before the watch starts.
+	stale := testMessage("stale", true, nil)
+	cs := NewClientsetWithSelectors(stale)
+	messages := cs.MessageV1().Messages("team-a")
+	w, err := messages.Watch(ctx, metav1.ListOptions{FieldSelector: "spec.urgent=true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	message := testMessage("disk-full", false, nil)
+	steps := []struct {
+		name   string
+		change func() error
+		want   watch.EventType
+		urgent bool
+	}{
+		{name: "create not matching", change: func() error {
+			_, err := messages.Create(ctx, message, metav1.CreateOptions{})
+			return err
+		}},
+		{name: "start matching", change: func() error {
+			message.Spec.Urgent = true
+			_, err := messages.Update(ctx, message, metav1.UpdateOptions{})
+			return err
+		}, want: watch.Added, urgent: true},
+		{name: "keep matching", change: func() error {
+			message.Spec.Context = "disk /var is 99% full"
+			_, err := messages.Update(ctx, message, metav1.UpdateOptions{})
+			return err
+		}, want: watch.Modified, urgent: true},
+		{name: "stop matching", change: func() error {
+			message.Spec.Urgent = false
+			_, err := messages.Update(ctx, message, metav1.UpdateOptions{})
+			return err
+		}, want: watch.Deleted, urgent: true},
+		{name: "delete not matching", change: func() error {
+			return messages.Delete(ctx, message.Name, metav1.DeleteOptions{})
+		}},
+		{name: "object from before the watch stops matching", change: func() error {
+			stale.Spec.Urgent = false
+			_, err := messages.Update(ctx, stale, metav1.UpdateOptions{})
+			return err
+		}, want: watch.Deleted, urgent: true},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		select {
+		case event := <-w.ResultChan():
+			if step.want == "" {
+				t.Fatalf("%s: got unexpected %s event", step.name, event.Type)
+			}
+			if event.Type != step.want {
+				t.Fatalf("%s: got %s event, want %s", step.name, event.Type, step.want)
+			}
+			if got := event.Object.(*messagev1.Message); got.Spec.Urgent != step.urgent {
+				t.Errorf("%s: got object with spec.urgent %v, want %v", step.name, got.Spec.Urgent, step.urgent)
+			}
+		case <-time.After(100 * time.Millisecond):
+			if step.want != "" {
+				t.Fatalf("%s: got no event, want %s", step.name, step.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	applyconfigurationmessagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	scheme "github.com/yasker/example-crd/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// EscalationPoliciesGetter has a method to return a EscalationPolicyInterface.
+// A group's client should implement this interface.
+type EscalationPoliciesGetter interface {
+	EscalationPolicies(namespace string) EscalationPolicyInterface
+}
+
+// EscalationPolicyInterface has methods to work with EscalationPolicy resources.
+type EscalationPolicyInterface interface {
+	Create(ctx context.Context, escalationPolicy *messagev1.EscalationPolicy, opts metav1.CreateOptions) (*messagev1.EscalationPolicy, error)
+	Update(ctx context.Context, escalationPolicy *messagev1.EscalationPolicy, opts metav1.UpdateOptions) (*messagev1.EscalationPolicy, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*messagev1.EscalationPolicy, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*messagev1.EscalationPolicyList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *messagev1.EscalationPolicy, err error)
+	Apply(ctx context.Context, escalationPolicy *applyconfigurationmessagev1.EscalationPolicyApplyConfiguration, opts metav1.ApplyOptions) (result *messagev1.EscalationPolicy, err error)
+	EscalationPolicyExpansion
+}
+
+// escalationPolicies implements EscalationPolicyInterface
+type escalationPolicies struct {
+	*gentype.ClientWithListAndApply[*messagev1.EscalationPolicy, *messagev1.EscalationPolicyList, *applyconfigurationmessagev1.EscalationPolicyApplyConfiguration]
+}
+
+// newEscalationPolicies returns a EscalationPolicies
+func newEscalationPolicies(c *MessageV1Client, namespace string) *escalationPolicies {
+	return &escalationPolicies{
+		gentype.NewClientWithListAndApply[*messagev1.EscalationPolicy, *messagev1.EscalationPolicyList, *applyconfigurationmessagev1.EscalationPolicyApplyConfiguration](
+			"escalationpolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *messagev1.EscalationPolicy { return &messagev1.EscalationPolicy{} },
+			func() *messagev1.EscalationPolicyList { return &messagev1.EscalationPolicyList{} },
+		),
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeBroadcastChannels implements BroadcastChannelInterface
+type fakeBroadcastChannels struct {
+	*gentype.FakeClientWithListAndApply[*v1.BroadcastChannel, *v1.BroadcastChannelList, *messagev1.BroadcastChannelApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeBroadcastChannels(fake *FakeMessageV1) typedmessagev1.BroadcastChannelInterface {
+	return &fakeBroadcastChannels{
+		gentype.NewFakeClientWithListAndApply[*v1.BroadcastChannel, *v1.BroadcastChannelList, *messagev1.BroadcastChannelApplyConfiguration](
+			fake.Fake,
+			"",
+			v1.SchemeGroupVersion.WithResource("broadcastchannels"),
+			v1.SchemeGroupVersion.WithKind("BroadcastChannel"),
+			func() *v1.BroadcastChannel { return &v1.BroadcastChannel{} },
+			func() *v1.BroadcastChannelList { return &v1.BroadcastChannelList{} },
+			func(dst, src *v1.BroadcastChannelList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.BroadcastChannelList) []*v1.BroadcastChannel { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.BroadcastChannelList, items []*v1.BroadcastChannel) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeDeadLetters implements DeadLetterInterface
+type fakeDeadLetters struct {
+	*gentype.FakeClientWithListAndApply[*v1.DeadLetter, *v1.DeadLetterList, *messagev1.DeadLetterApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeDeadLetters(fake *FakeMessageV1, namespace string) typedmessagev1.DeadLetterInterface {
+	return &fakeDeadLetters{
+		gentype.NewFakeClientWithListAndApply[*v1.DeadLetter, *v1.DeadLetterList, *messagev1.DeadLetterApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("deadletters"),
+			v1.SchemeGroupVersion.WithKind("DeadLetter"),
+			func() *v1.DeadLetter { return &v1.DeadLetter{} },
+			func() *v1.DeadLetterList { return &v1.DeadLetterList{} },
+			func(dst, src *v1.DeadLetterList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.DeadLetterList) []*v1.DeadLetter { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.DeadLetterList, items []*v1.DeadLetter) { list.Items = gentype.FromPointerSlice(items) },
+		),
+		fake,
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeEscalationPolicies implements EscalationPolicyInterface
+type fakeEscalationPolicies struct {
+	*gentype.FakeClientWithListAndApply[*v1.EscalationPolicy, *v1.EscalationPolicyList, *messagev1.EscalationPolicyApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeEscalationPolicies(fake *FakeMessageV1, namespace string) typedmessagev1.EscalationPolicyInterface {
+	return &fakeEscalationPolicies{
+		gentype.NewFakeClientWithListAndApply[*v1.EscalationPolicy, *v1.EscalationPolicyList, *messagev1.EscalationPolicyApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("escalationpolicies"),
+			v1.SchemeGroupVersion.WithKind("EscalationPolicy"),
+			func() *v1.EscalationPolicy { return &v1.EscalationPolicy{} },
+			func() *v1.EscalationPolicyList { return &v1.EscalationPolicyList{} },
+			func(dst, src *v1.EscalationPolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.EscalationPolicyList) []*v1.EscalationPolicy { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.EscalationPolicyList, items []*v1.EscalationPolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
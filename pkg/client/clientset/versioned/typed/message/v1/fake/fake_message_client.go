@@ -14,6 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Code generated by client-gen. DO NOT EDIT.
+
 package fake
 
 import (
@@ -26,8 +28,32 @@ type FakeMessageV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeMessageV1) BroadcastChannels() v1.BroadcastChannelInterface {
+	return newFakeBroadcastChannels(c)
+}
+
+func (c *FakeMessageV1) DeadLetters(namespace string) v1.DeadLetterInterface {
+	return newFakeDeadLetters(c, namespace)
+}
+
+func (c *FakeMessageV1) EscalationPolicies(namespace string) v1.EscalationPolicyInterface {
+	return newFakeEscalationPolicies(c, namespace)
+}
+
 func (c *FakeMessageV1) Messages(namespace string) v1.MessageInterface {
-	return &FakeMessages{c, namespace}
+	return newFakeMessages(c, namespace)
+}
+
+func (c *FakeMessageV1) MessageAcknowledgements(namespace string) v1.MessageAcknowledgementInterface {
+	return newFakeMessageAcknowledgements(c, namespace)
+}
+
+func (c *FakeMessageV1) MessageTemplates(namespace string) v1.MessageTemplateInterface {
+	return newFakeMessageTemplates(c, namespace)
+}
+
+func (c *FakeMessageV1) Subscriptions(namespace string) v1.SubscriptionInterface {
+	return newFakeSubscriptions(c, namespace)
 }
 
 // RESTClient returns a RESTClient that is used to communicate
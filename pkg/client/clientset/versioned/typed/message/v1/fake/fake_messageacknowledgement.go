@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeMessageAcknowledgements implements MessageAcknowledgementInterface
+type fakeMessageAcknowledgements struct {
+	*gentype.FakeClientWithListAndApply[*v1.MessageAcknowledgement, *v1.MessageAcknowledgementList, *messagev1.MessageAcknowledgementApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeMessageAcknowledgements(fake *FakeMessageV1, namespace string) typedmessagev1.MessageAcknowledgementInterface {
+	return &fakeMessageAcknowledgements{
+		gentype.NewFakeClientWithListAndApply[*v1.MessageAcknowledgement, *v1.MessageAcknowledgementList, *messagev1.MessageAcknowledgementApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("messageacknowledgements"),
+			v1.SchemeGroupVersion.WithKind("MessageAcknowledgement"),
+			func() *v1.MessageAcknowledgement { return &v1.MessageAcknowledgement{} },
+			func() *v1.MessageAcknowledgementList { return &v1.MessageAcknowledgementList{} },
+			func(dst, src *v1.MessageAcknowledgementList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.MessageAcknowledgementList) []*v1.MessageAcknowledgement {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.MessageAcknowledgementList, items []*v1.MessageAcknowledgement) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
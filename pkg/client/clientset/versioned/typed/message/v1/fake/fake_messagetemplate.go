@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeMessageTemplates implements MessageTemplateInterface
+type fakeMessageTemplates struct {
+	*gentype.FakeClientWithListAndApply[*v1.MessageTemplate, *v1.MessageTemplateList, *messagev1.MessageTemplateApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeMessageTemplates(fake *FakeMessageV1, namespace string) typedmessagev1.MessageTemplateInterface {
+	return &fakeMessageTemplates{
+		gentype.NewFakeClientWithListAndApply[*v1.MessageTemplate, *v1.MessageTemplateList, *messagev1.MessageTemplateApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("messagetemplates"),
+			v1.SchemeGroupVersion.WithKind("MessageTemplate"),
+			func() *v1.MessageTemplate { return &v1.MessageTemplate{} },
+			func() *v1.MessageTemplateList { return &v1.MessageTemplateList{} },
+			func(dst, src *v1.MessageTemplateList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.MessageTemplateList) []*v1.MessageTemplate { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.MessageTemplateList, items []*v1.MessageTemplate) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	messagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	typedmessagev1 "github.com/yasker/example-crd/pkg/client/clientset/versioned/typed/message/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeSubscriptions implements SubscriptionInterface
+type fakeSubscriptions struct {
+	*gentype.FakeClientWithListAndApply[*v1.Subscription, *v1.SubscriptionList, *messagev1.SubscriptionApplyConfiguration]
+	Fake *FakeMessageV1
+}
+
+func newFakeSubscriptions(fake *FakeMessageV1, namespace string) typedmessagev1.SubscriptionInterface {
+	return &fakeSubscriptions{
+		gentype.NewFakeClientWithListAndApply[*v1.Subscription, *v1.SubscriptionList, *messagev1.SubscriptionApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("subscriptions"),
+			v1.SchemeGroupVersion.WithKind("Subscription"),
+			func() *v1.Subscription { return &v1.Subscription{} },
+			func() *v1.SubscriptionList { return &v1.SubscriptionList{} },
+			func(dst, src *v1.SubscriptionList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.SubscriptionList) []*v1.Subscription { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.SubscriptionList, items []*v1.Subscription) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -14,6 +14,20 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Code generated by client-gen. DO NOT EDIT.
+
 package v1
 
+type BroadcastChannelExpansion interface{}
+
+type DeadLetterExpansion interface{}
+
+type EscalationPolicyExpansion interface{}
+
 type MessageExpansion interface{}
+
+type MessageAcknowledgementExpansion interface{}
+
+type MessageTemplateExpansion interface{}
+
+type SubscriptionExpansion interface{}
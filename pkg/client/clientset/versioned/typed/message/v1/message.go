@@ -14,15 +14,20 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Code generated by client-gen. DO NOT EDIT.
+
 package v1
 
 import (
-	v1 "github.com/yasker/example-crd/apis/message/v1"
+	context "context"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	applyconfigurationmessagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
 	scheme "github.com/yasker/example-crd/pkg/client/clientset/versioned/scheme"
-	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
-	rest "k8s.io/client-go/rest"
+	gentype "k8s.io/client-go/gentype"
 )
 
 // MessagesGetter has a method to return a MessageInterface.
@@ -33,140 +38,37 @@ type MessagesGetter interface {
 
 // MessageInterface has methods to work with Message resources.
 type MessageInterface interface {
-	Create(*v1.Message) (*v1.Message, error)
-	Update(*v1.Message) (*v1.Message, error)
-	UpdateStatus(*v1.Message) (*v1.Message, error)
-	Delete(name string, options *meta_v1.DeleteOptions) error
-	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
-	Get(name string, options meta_v1.GetOptions) (*v1.Message, error)
-	List(opts meta_v1.ListOptions) (*v1.MessageList, error)
-	Watch(opts meta_v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Message, err error)
+	Create(ctx context.Context, message *messagev1.Message, opts metav1.CreateOptions) (*messagev1.Message, error)
+	Update(ctx context.Context, message *messagev1.Message, opts metav1.UpdateOptions) (*messagev1.Message, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, message *messagev1.Message, opts metav1.UpdateOptions) (*messagev1.Message, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*messagev1.Message, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*messagev1.MessageList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *messagev1.Message, err error)
+	Apply(ctx context.Context, message *applyconfigurationmessagev1.MessageApplyConfiguration, opts metav1.ApplyOptions) (result *messagev1.Message, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, message *applyconfigurationmessagev1.MessageApplyConfiguration, opts metav1.ApplyOptions) (result *messagev1.Message, err error)
 	MessageExpansion
 }
 
 // messages implements MessageInterface
 type messages struct {
-	client rest.Interface
-	ns     string
+	*gentype.ClientWithListAndApply[*messagev1.Message, *messagev1.MessageList, *applyconfigurationmessagev1.MessageApplyConfiguration]
 }
 
 // newMessages returns a Messages
 func newMessages(c *MessageV1Client, namespace string) *messages {
 	return &messages{
-		client: c.RESTClient(),
-		ns:     namespace,
+		gentype.NewClientWithListAndApply[*messagev1.Message, *messagev1.MessageList, *applyconfigurationmessagev1.MessageApplyConfiguration](
+			"messages",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *messagev1.Message { return &messagev1.Message{} },
+			func() *messagev1.MessageList { return &messagev1.MessageList{} },
+		),
 	}
 }
-
-// Get takes name of the message, and returns the corresponding message object, and an error if there is any.
-func (c *messages) Get(name string, options meta_v1.GetOptions) (result *v1.Message, err error) {
-	result = &v1.Message{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("messages").
-		Name(name).
-		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
-		Into(result)
-	return
-}
-
-// List takes label and field selectors, and returns the list of Messages that match those selectors.
-func (c *messages) List(opts meta_v1.ListOptions) (result *v1.MessageList, err error) {
-	result = &v1.MessageList{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("messages").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
-		Into(result)
-	return
-}
-
-// Watch returns a watch.Interface that watches the requested messages.
-func (c *messages) Watch(opts meta_v1.ListOptions) (watch.Interface, error) {
-	opts.Watch = true
-	return c.client.Get().
-		Namespace(c.ns).
-		Resource("messages").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
-}
-
-// Create takes the representation of a message and creates it.  Returns the server's representation of the message, and an error, if there is any.
-func (c *messages) Create(message *v1.Message) (result *v1.Message, err error) {
-	result = &v1.Message{}
-	err = c.client.Post().
-		Namespace(c.ns).
-		Resource("messages").
-		Body(message).
-		Do().
-		Into(result)
-	return
-}
-
-// Update takes the representation of a message and updates it. Returns the server's representation of the message, and an error, if there is any.
-func (c *messages) Update(message *v1.Message) (result *v1.Message, err error) {
-	result = &v1.Message{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("messages").
-		Name(message.Name).
-		Body(message).
-		Do().
-		Into(result)
-	return
-}
-
-// UpdateStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-
-func (c *messages) UpdateStatus(message *v1.Message) (result *v1.Message, err error) {
-	result = &v1.Message{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("messages").
-		Name(message.Name).
-		SubResource("status").
-		Body(message).
-		Do().
-		Into(result)
-	return
-}
-
-// Delete takes name of the message and deletes it. Returns an error if one occurs.
-func (c *messages) Delete(name string, options *meta_v1.DeleteOptions) error {
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("messages").
-		Name(name).
-		Body(options).
-		Do().
-		Error()
-}
-
-// DeleteCollection deletes a collection of objects.
-func (c *messages) DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error {
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("messages").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
-		Error()
-}
-
-// Patch applies the patch and returns the patched message.
-func (c *messages) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Message, err error) {
-	result = &v1.Message{}
-	err = c.client.Patch(pt).
-		Namespace(c.ns).
-		Resource("messages").
-		SubResource(subresources...).
-		Name(name).
-		Body(data).
-		Do().
-		Into(result)
-	return
-}
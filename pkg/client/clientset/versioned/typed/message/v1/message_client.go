@@ -14,36 +14,81 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Code generated by client-gen. DO NOT EDIT.
+
 package v1
 
 import (
-	v1 "github.com/yasker/example-crd/apis/message/v1"
-	"github.com/yasker/example-crd/pkg/client/clientset/versioned/scheme"
-	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	http "net/http"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	scheme "github.com/yasker/example-crd/pkg/client/clientset/versioned/scheme"
 	rest "k8s.io/client-go/rest"
 )
 
 type MessageV1Interface interface {
 	RESTClient() rest.Interface
+	BroadcastChannelsGetter
+	DeadLettersGetter
+	EscalationPoliciesGetter
 	MessagesGetter
+	MessageAcknowledgementsGetter
+	MessageTemplatesGetter
+	SubscriptionsGetter
 }
 
-// MessageV1Client is used to interact with features provided by the message group.
+// MessageV1Client is used to interact with features provided by the example.rancher.io group.
 type MessageV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *MessageV1Client) BroadcastChannels() BroadcastChannelInterface {
+	return newBroadcastChannels(c)
+}
+
+func (c *MessageV1Client) DeadLetters(namespace string) DeadLetterInterface {
+	return newDeadLetters(c, namespace)
+}
+
+func (c *MessageV1Client) EscalationPolicies(namespace string) EscalationPolicyInterface {
+	return newEscalationPolicies(c, namespace)
+}
+
 func (c *MessageV1Client) Messages(namespace string) MessageInterface {
 	return newMessages(c, namespace)
 }
 
+func (c *MessageV1Client) MessageAcknowledgements(namespace string) MessageAcknowledgementInterface {
+	return newMessageAcknowledgements(c, namespace)
+}
+
+func (c *MessageV1Client) MessageTemplates(namespace string) MessageTemplateInterface {
+	return newMessageTemplates(c, namespace)
+}
+
+func (c *MessageV1Client) Subscriptions(namespace string) SubscriptionInterface {
+	return newSubscriptions(c, namespace)
+}
+
 // NewForConfig creates a new MessageV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
 func NewForConfig(c *rest.Config) (*MessageV1Client, error) {
 	config := *c
-	if err := setConfigDefaults(&config); err != nil {
+	setConfigDefaults(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
 		return nil, err
 	}
-	client, err := rest.RESTClientFor(&config)
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new MessageV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*MessageV1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
 	if err != nil {
 		return nil, err
 	}
@@ -65,17 +110,15 @@ func New(c rest.Interface) *MessageV1Client {
 	return &MessageV1Client{c}
 }
 
-func setConfigDefaults(config *rest.Config) error {
-	gv := v1.SchemeGroupVersion
+func setConfigDefaults(config *rest.Config) {
+	gv := messagev1.SchemeGroupVersion
 	config.GroupVersion = &gv
 	config.APIPath = "/apis"
-	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: scheme.Codecs}
+	config.NegotiatedSerializer = rest.CodecFactoryForGeneratedClient(scheme.Scheme, scheme.Codecs).WithoutConversion()
 
 	if config.UserAgent == "" {
 		config.UserAgent = rest.DefaultKubernetesUserAgent()
 	}
-
-	return nil
 }
 
 // RESTClient returns a RESTClient that is used to communicate
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	applyconfigurationmessagev1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	scheme "github.com/yasker/example-crd/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// MessageTemplatesGetter has a method to return a MessageTemplateInterface.
+// A group's client should implement this interface.
+type MessageTemplatesGetter interface {
+	MessageTemplates(namespace string) MessageTemplateInterface
+}
+
+// MessageTemplateInterface has methods to work with MessageTemplate resources.
+type MessageTemplateInterface interface {
+	Create(ctx context.Context, messageTemplate *messagev1.MessageTemplate, opts metav1.CreateOptions) (*messagev1.MessageTemplate, error)
+	Update(ctx context.Context, messageTemplate *messagev1.MessageTemplate, opts metav1.UpdateOptions) (*messagev1.MessageTemplate, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*messagev1.MessageTemplate, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*messagev1.MessageTemplateList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *messagev1.MessageTemplate, err error)
+	Apply(ctx context.Context, messageTemplate *applyconfigurationmessagev1.MessageTemplateApplyConfiguration, opts metav1.ApplyOptions) (result *messagev1.MessageTemplate, err error)
+	MessageTemplateExpansion
+}
+
+// messageTemplates implements MessageTemplateInterface
+type messageTemplates struct {
+	*gentype.ClientWithListAndApply[*messagev1.MessageTemplate, *messagev1.MessageTemplateList, *applyconfigurationmessagev1.MessageTemplateApplyConfiguration]
+}
+
+// newMessageTemplates returns a MessageTemplates
+func newMessageTemplates(c *MessageV1Client, namespace string) *messageTemplates {
+	return &messageTemplates{
+		gentype.NewClientWithListAndApply[*messagev1.MessageTemplate, *messagev1.MessageTemplateList, *applyconfigurationmessagev1.MessageTemplateApplyConfiguration](
+			"messagetemplates",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *messagev1.MessageTemplate { return &messagev1.MessageTemplate{} },
+			func() *messagev1.MessageTemplateList { return &messagev1.MessageTemplateList{} },
+		),
+	}
+}
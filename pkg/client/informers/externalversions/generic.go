@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	fmt "fmt"
+
+	v1 "github.com/yasker/example-crd/apis/message/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=example.rancher.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("broadcastchannels"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().BroadcastChannels().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("deadletters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().DeadLetters().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("escalationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().EscalationPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("messages"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().Messages().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("messageacknowledgements"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().MessageAcknowledgements().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("messagetemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().MessageTemplates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("subscriptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Message().V1().Subscriptions().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+	time "time"
+
+	apismessagev1 "github.com/yasker/example-crd/apis/message/v1"
+	versioned "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/yasker/example-crd/pkg/client/informers/externalversions/internalinterfaces"
+	messagev1 "github.com/yasker/example-crd/pkg/client/listers/message/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// EscalationPolicyInformer provides access to a shared informer and lister for
+// EscalationPolicies.
+type EscalationPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() messagev1.EscalationPolicyLister
+}
+
+type escalationPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewEscalationPolicyInformer constructs a new informer for EscalationPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewEscalationPolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredEscalationPolicyInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredEscalationPolicyInformer constructs a new informer for EscalationPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredEscalationPolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.MessageV1().EscalationPolicies(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.MessageV1().EscalationPolicies(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.MessageV1().EscalationPolicies(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.MessageV1().EscalationPolicies(namespace).Watch(ctx, options)
+			},
+		},
+		&apismessagev1.EscalationPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *escalationPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredEscalationPolicyInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *escalationPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apismessagev1.EscalationPolicy{}, f.defaultInformer)
+}
+
+func (f *escalationPolicyInformer) Lister() messagev1.EscalationPolicyLister {
+	return messagev1.NewEscalationPolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	internalinterfaces "github.com/yasker/example-crd/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// BroadcastChannels returns a BroadcastChannelInformer.
+	BroadcastChannels() BroadcastChannelInformer
+	// DeadLetters returns a DeadLetterInformer.
+	DeadLetters() DeadLetterInformer
+	// EscalationPolicies returns a EscalationPolicyInformer.
+	EscalationPolicies() EscalationPolicyInformer
+	// Messages returns a MessageInformer.
+	Messages() MessageInformer
+	// MessageAcknowledgements returns a MessageAcknowledgementInformer.
+	MessageAcknowledgements() MessageAcknowledgementInformer
+	// MessageTemplates returns a MessageTemplateInformer.
+	MessageTemplates() MessageTemplateInformer
+	// Subscriptions returns a SubscriptionInformer.
+	Subscriptions() SubscriptionInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// BroadcastChannels returns a BroadcastChannelInformer.
+func (v *version) BroadcastChannels() BroadcastChannelInformer {
+	return &broadcastChannelInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// DeadLetters returns a DeadLetterInformer.
+func (v *version) DeadLetters() DeadLetterInformer {
+	return &deadLetterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// EscalationPolicies returns a EscalationPolicyInformer.
+func (v *version) EscalationPolicies() EscalationPolicyInformer {
+	return &escalationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Messages returns a MessageInformer.
+func (v *version) Messages() MessageInformer {
+	return &messageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// MessageAcknowledgements returns a MessageAcknowledgementInformer.
+func (v *version) MessageAcknowledgements() MessageAcknowledgementInformer {
+	return &messageAcknowledgementInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// MessageTemplates returns a MessageTemplateInformer.
+func (v *version) MessageTemplates() MessageTemplateInformer {
+	return &messageTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Subscriptions returns a SubscriptionInformer.
+func (v *version) Subscriptions() SubscriptionInformer {
+	return &subscriptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// BroadcastChannelLister helps list BroadcastChannels.
+// All objects returned here must be treated as read-only.
+type BroadcastChannelLister interface {
+	// List lists all BroadcastChannels in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.BroadcastChannel, err error)
+	// Get retrieves the BroadcastChannel from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.BroadcastChannel, error)
+	BroadcastChannelListerExpansion
+}
+
+// broadcastChannelLister implements the BroadcastChannelLister interface.
+type broadcastChannelLister struct {
+	listers.ResourceIndexer[*messagev1.BroadcastChannel]
+}
+
+// NewBroadcastChannelLister returns a new BroadcastChannelLister.
+func NewBroadcastChannelLister(indexer cache.Indexer) BroadcastChannelLister {
+	return &broadcastChannelLister{listers.New[*messagev1.BroadcastChannel](indexer, messagev1.Resource("broadcastchannel"))}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DeadLetterLister helps list DeadLetters.
+// All objects returned here must be treated as read-only.
+type DeadLetterLister interface {
+	// List lists all DeadLetters in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.DeadLetter, err error)
+	// DeadLetters returns an object that can list and get DeadLetters.
+	DeadLetters(namespace string) DeadLetterNamespaceLister
+	DeadLetterListerExpansion
+}
+
+// deadLetterLister implements the DeadLetterLister interface.
+type deadLetterLister struct {
+	listers.ResourceIndexer[*messagev1.DeadLetter]
+}
+
+// NewDeadLetterLister returns a new DeadLetterLister.
+func NewDeadLetterLister(indexer cache.Indexer) DeadLetterLister {
+	return &deadLetterLister{listers.New[*messagev1.DeadLetter](indexer, messagev1.Resource("deadletter"))}
+}
+
+// DeadLetters returns an object that can list and get DeadLetters.
+func (s *deadLetterLister) DeadLetters(namespace string) DeadLetterNamespaceLister {
+	return deadLetterNamespaceLister{listers.NewNamespaced[*messagev1.DeadLetter](s.ResourceIndexer, namespace)}
+}
+
+// DeadLetterNamespaceLister helps list and get DeadLetters.
+// All objects returned here must be treated as read-only.
+type DeadLetterNamespaceLister interface {
+	// List lists all DeadLetters in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.DeadLetter, err error)
+	// Get retrieves the DeadLetter from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.DeadLetter, error)
+	DeadLetterNamespaceListerExpansion
+}
+
+// deadLetterNamespaceLister implements the DeadLetterNamespaceLister
+// interface.
+type deadLetterNamespaceLister struct {
+	listers.ResourceIndexer[*messagev1.DeadLetter]
+}
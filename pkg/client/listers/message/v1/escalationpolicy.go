@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// EscalationPolicyLister helps list EscalationPolicies.
+// All objects returned here must be treated as read-only.
+type EscalationPolicyLister interface {
+	// List lists all EscalationPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.EscalationPolicy, err error)
+	// EscalationPolicies returns an object that can list and get EscalationPolicies.
+	EscalationPolicies(namespace string) EscalationPolicyNamespaceLister
+	EscalationPolicyListerExpansion
+}
+
+// escalationPolicyLister implements the EscalationPolicyLister interface.
+type escalationPolicyLister struct {
+	listers.ResourceIndexer[*messagev1.EscalationPolicy]
+}
+
+// NewEscalationPolicyLister returns a new EscalationPolicyLister.
+func NewEscalationPolicyLister(indexer cache.Indexer) EscalationPolicyLister {
+	return &escalationPolicyLister{listers.New[*messagev1.EscalationPolicy](indexer, messagev1.Resource("escalationpolicy"))}
+}
+
+// EscalationPolicies returns an object that can list and get EscalationPolicies.
+func (s *escalationPolicyLister) EscalationPolicies(namespace string) EscalationPolicyNamespaceLister {
+	return escalationPolicyNamespaceLister{listers.NewNamespaced[*messagev1.EscalationPolicy](s.ResourceIndexer, namespace)}
+}
+
+// EscalationPolicyNamespaceLister helps list and get EscalationPolicies.
+// All objects returned here must be treated as read-only.
+type EscalationPolicyNamespaceLister interface {
+	// List lists all EscalationPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.EscalationPolicy, err error)
+	// Get retrieves the EscalationPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.EscalationPolicy, error)
+	EscalationPolicyNamespaceListerExpansion
+}
+
+// escalationPolicyNamespaceLister implements the EscalationPolicyNamespaceLister
+// interface.
+type escalationPolicyNamespaceLister struct {
+	listers.ResourceIndexer[*messagev1.EscalationPolicy]
+}
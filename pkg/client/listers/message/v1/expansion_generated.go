@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// BroadcastChannelListerExpansion allows custom methods to be added to
+// BroadcastChannelLister.
+type BroadcastChannelListerExpansion interface{}
+
+// DeadLetterListerExpansion allows custom methods to be added to
+// DeadLetterLister.
+type DeadLetterListerExpansion interface{}
+
+// DeadLetterNamespaceListerExpansion allows custom methods to be added to
+// DeadLetterNamespaceLister.
+type DeadLetterNamespaceListerExpansion interface{}
+
+// EscalationPolicyListerExpansion allows custom methods to be added to
+// EscalationPolicyLister.
+type EscalationPolicyListerExpansion interface{}
+
+// EscalationPolicyNamespaceListerExpansion allows custom methods to be added to
+// EscalationPolicyNamespaceLister.
+type EscalationPolicyNamespaceListerExpansion interface{}
+
+// MessageListerExpansion allows custom methods to be added to
+// MessageLister.
+type MessageListerExpansion interface{}
+
+// MessageNamespaceListerExpansion allows custom methods to be added to
+// MessageNamespaceLister.
+type MessageNamespaceListerExpansion interface{}
+
+// MessageAcknowledgementListerExpansion allows custom methods to be added to
+// MessageAcknowledgementLister.
+type MessageAcknowledgementListerExpansion interface{}
+
+// MessageAcknowledgementNamespaceListerExpansion allows custom methods to be added to
+// MessageAcknowledgementNamespaceLister.
+type MessageAcknowledgementNamespaceListerExpansion interface{}
+
+// MessageTemplateListerExpansion allows custom methods to be added to
+// MessageTemplateLister.
+type MessageTemplateListerExpansion interface{}
+
+// MessageTemplateNamespaceListerExpansion allows custom methods to be added to
+// MessageTemplateNamespaceLister.
+type MessageTemplateNamespaceListerExpansion interface{}
+
+// SubscriptionListerExpansion allows custom methods to be added to
+// SubscriptionLister.
+type SubscriptionListerExpansion interface{}
+
+// SubscriptionNamespaceListerExpansion allows custom methods to be added to
+// SubscriptionNamespaceLister.
+type SubscriptionNamespaceListerExpansion interface{}
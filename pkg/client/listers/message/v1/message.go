@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// MessageLister helps list Messages.
+// All objects returned here must be treated as read-only.
+type MessageLister interface {
+	// List lists all Messages in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.Message, err error)
+	// Messages returns an object that can list and get Messages.
+	Messages(namespace string) MessageNamespaceLister
+	MessageListerExpansion
+}
+
+// messageLister implements the MessageLister interface.
+type messageLister struct {
+	listers.ResourceIndexer[*messagev1.Message]
+}
+
+// NewMessageLister returns a new MessageLister.
+func NewMessageLister(indexer cache.Indexer) MessageLister {
+	return &messageLister{listers.New[*messagev1.Message](indexer, messagev1.Resource("message"))}
+}
+
+// Messages returns an object that can list and get Messages.
+func (s *messageLister) Messages(namespace string) MessageNamespaceLister {
+	return messageNamespaceLister{listers.NewNamespaced[*messagev1.Message](s.ResourceIndexer, namespace)}
+}
+
+// MessageNamespaceLister helps list and get Messages.
+// All objects returned here must be treated as read-only.
+type MessageNamespaceLister interface {
+	// List lists all Messages in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.Message, err error)
+	// Get retrieves the Message from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.Message, error)
+	MessageNamespaceListerExpansion
+}
+
+// messageNamespaceLister implements the MessageNamespaceLister
+// interface.
+type messageNamespaceLister struct {
+	listers.ResourceIndexer[*messagev1.Message]
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// MessageAcknowledgementLister helps list MessageAcknowledgements.
+// All objects returned here must be treated as read-only.
+type MessageAcknowledgementLister interface {
+	// List lists all MessageAcknowledgements in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.MessageAcknowledgement, err error)
+	// MessageAcknowledgements returns an object that can list and get MessageAcknowledgements.
+	MessageAcknowledgements(namespace string) MessageAcknowledgementNamespaceLister
+	MessageAcknowledgementListerExpansion
+}
+
+// messageAcknowledgementLister implements the MessageAcknowledgementLister interface.
+type messageAcknowledgementLister struct {
+	listers.ResourceIndexer[*messagev1.MessageAcknowledgement]
+}
+
+// NewMessageAcknowledgementLister returns a new MessageAcknowledgementLister.
+func NewMessageAcknowledgementLister(indexer cache.Indexer) MessageAcknowledgementLister {
+	return &messageAcknowledgementLister{listers.New[*messagev1.MessageAcknowledgement](indexer, messagev1.Resource("messageacknowledgement"))}
+}
+
+// MessageAcknowledgements returns an object that can list and get MessageAcknowledgements.
+func (s *messageAcknowledgementLister) MessageAcknowledgements(namespace string) MessageAcknowledgementNamespaceLister {
+	return messageAcknowledgementNamespaceLister{listers.NewNamespaced[*messagev1.MessageAcknowledgement](s.ResourceIndexer, namespace)}
+}
+
+// MessageAcknowledgementNamespaceLister helps list and get MessageAcknowledgements.
+// All objects returned here must be treated as read-only.
+type MessageAcknowledgementNamespaceLister interface {
+	// List lists all MessageAcknowledgements in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.MessageAcknowledgement, err error)
+	// Get retrieves the MessageAcknowledgement from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.MessageAcknowledgement, error)
+	MessageAcknowledgementNamespaceListerExpansion
+}
+
+// messageAcknowledgementNamespaceLister implements the MessageAcknowledgementNamespaceLister
+// interface.
+type messageAcknowledgementNamespaceLister struct {
+	listers.ResourceIndexer[*messagev1.MessageAcknowledgement]
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// MessageTemplateLister helps list MessageTemplates.
+// All objects returned here must be treated as read-only.
+type MessageTemplateLister interface {
+	// List lists all MessageTemplates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.MessageTemplate, err error)
+	// MessageTemplates returns an object that can list and get MessageTemplates.
+	MessageTemplates(namespace string) MessageTemplateNamespaceLister
+	MessageTemplateListerExpansion
+}
+
+// messageTemplateLister implements the MessageTemplateLister interface.
+type messageTemplateLister struct {
+	listers.ResourceIndexer[*messagev1.MessageTemplate]
+}
+
+// NewMessageTemplateLister returns a new MessageTemplateLister.
+func NewMessageTemplateLister(indexer cache.Indexer) MessageTemplateLister {
+	return &messageTemplateLister{listers.New[*messagev1.MessageTemplate](indexer, messagev1.Resource("messagetemplate"))}
+}
+
+// MessageTemplates returns an object that can list and get MessageTemplates.
+func (s *messageTemplateLister) MessageTemplates(namespace string) MessageTemplateNamespaceLister {
+	return messageTemplateNamespaceLister{listers.NewNamespaced[*messagev1.MessageTemplate](s.ResourceIndexer, namespace)}
+}
+
+// MessageTemplateNamespaceLister helps list and get MessageTemplates.
+// All objects returned here must be treated as read-only.
+type MessageTemplateNamespaceLister interface {
+	// List lists all MessageTemplates in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*messagev1.MessageTemplate, err error)
+	// Get retrieves the MessageTemplate from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*messagev1.MessageTemplate, error)
+	MessageTemplateNamespaceListerExpansion
+}
+
+// messageTemplateNamespaceLister implements the MessageTemplateNamespaceLister
+// interface.
+type messageTemplateNamespaceLister struct {
+	listers.ResourceIndexer[*messagev1.MessageTemplate]
+}
@@ -0,0 +1,11 @@
+#!/bin/bash
+
+set -e
+
+openapi-gen --output-file zz_generated.openapi.go --output-dir pkg/client/openapi --output-pkg github.com/yasker/example-crd/pkg/client/openapi --report-filename /dev/null --go-header-file pkg/script/boilerplate.go.txt k8s.io/apimachinery/pkg/apis/meta/v1 k8s.io/apimachinery/pkg/runtime k8s.io/apimachinery/pkg/version ./apis/message/v1
+
+schema=$(mktemp)
+trap "rm -f ${schema}" EXIT
+go run ./pkg/client/openapi/cmd/models-schema > ${schema}
+
+applyconfiguration-gen --output-dir pkg/client/applyconfiguration --output-pkg github.com/yasker/example-crd/pkg/client/applyconfiguration --openapi-schema ${schema} --go-header-file pkg/script/boilerplate.go.txt ./apis/message/v1
@@ -1,3 +1,3 @@
 #!/bin/bash
 
-client-gen --clientset-name versioned --input-base 'github.com/yasker/example-crd/apis' --input "message/v1" --clientset-path github.com/yasker/example-crd/pkg/client/clientset
+client-gen --clientset-name versioned --input-base 'github.com/yasker/example-crd/apis' --input "message/v1" --output-dir pkg/client/clientset --output-pkg github.com/yasker/example-crd/pkg/client/clientset --apply-configuration-package github.com/yasker/example-crd/pkg/client/applyconfiguration --go-header-file pkg/script/boilerplate.go.txt
@@ -1,4 +1,3 @@
 #!/bin/bash
 
-deepcopy-gen --input-dirs github.com/yasker/example-crd/apis/message/v1 -O zz_generated.deepcopy --bounding-dirs github.com/yasker/example-crd/apis
-
+deepcopy-gen --output-file zz_generated.deepcopy.go --go-header-file pkg/script/boilerplate.go.txt ./apis/message/v1
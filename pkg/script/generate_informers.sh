@@ -0,0 +1,5 @@
+#!/bin/bash
+
+lister-gen --output-dir pkg/client/listers --output-pkg github.com/yasker/example-crd/pkg/client/listers --go-header-file pkg/script/boilerplate.go.txt ./apis/message/v1
+
+informer-gen --output-dir pkg/client/informers --output-pkg github.com/yasker/example-crd/pkg/client/informers --versioned-clientset-package github.com/yasker/example-crd/pkg/client/clientset/versioned --listers-package github.com/yasker/example-crd/pkg/client/listers --go-header-file pkg/script/boilerplate.go.txt ./apis/message/v1
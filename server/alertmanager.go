@@ -0,0 +1,296 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+)
+
+// maxAlertPayload is the largest Alertmanager payload accepted.
+const maxAlertPayload = 1 << 20
+
+// AlertmanagerPayload is the body of the requests of the Alertmanager
+// webhook receiver, version 4.
+type AlertmanagerPayload struct {
+	Version  string  `json:"version"`
+	GroupKey string  `json:"groupKey"`
+	Status   string  `json:"status"`
+	Receiver string  `json:"receiver"`
+	Alerts   []Alert `json:"alerts"`
+}
+
+// Alert is an alert of an AlertmanagerPayload.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertReceiver is an Alertmanager webhook receiver creating a Message for
+// each firing alert, and marking it resolved once the alert is.
+//
+// Alertmanager sends the same alert again until it resolves, so the
+// Message is named after the fingerprint of the alert and the time it
+// started firing: notifications of the same alert find the Message they
+// created, while the alert firing again later creates a new one.
+//
+// Alertmanager authenticates with a bearer token, checked with a
+// TokenReview, e.g. the token of its service account. It needs permission to
+// create Messages in the namespaces of the alerts, and to patch them to mark
+// them resolved, checked with SubjectAccessReviews, so that alerts can't put
+// Messages where their sender couldn't. A notification with an alert its
+// sender may not receive is rejected as a whole.
+type AlertReceiver struct {
+	// DefaultNamespace is the namespace of the Messages of alerts without
+	// a valid NamespaceLabel.
+	DefaultNamespace string
+	// NamespaceLabel is the label of the alerts naming the namespace of
+	// their Message.
+	NamespaceLabel string
+	// UrgentSeverities are the values of the "severity" label of the alerts
+	// whose Messages are urgent.
+	UrgentSeverities []string
+	// Topic and ChannelName are the topic and channel of the Messages.
+	Topic       string
+	ChannelName string
+
+	client   messageClientset.Interface
+	reviewer *tokenReviewer
+}
+
+func NewAlertReceiver(client messageClientset.Interface, kubeClient kubernetes.Interface) *AlertReceiver {
+	return &AlertReceiver{
+		DefaultNamespace: "default",
+		NamespaceLabel:   "namespace",
+		UrgentSeverities: []string{"critical"},
+		client:           client,
+		reviewer:         newTokenReviewer(kubeClient, false),
+	}
+}
+
+func (a *AlertReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	user, ok := a.reviewer.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var payload AlertmanagerPayload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAlertPayload)).Decode(&payload); err != nil {
+		http.Error(w, fmt.Sprintf("decoding the payload: %v", err), http.StatusBadRequest)
+		return
+	}
+	if payload.Version != "" && payload.Version != "4" {
+		http.Error(w, fmt.Sprintf("unsupported payload version %q", payload.Version), http.StatusBadRequest)
+		return
+	}
+
+	// Alertmanager doesn't retry client errors, so a notification with an
+	// alert its sender may not receive is rejected before any is.
+	for i := range payload.Alerts {
+		if err := a.authorize(r.Context(), user, &payload.Alerts[i]); err != nil {
+			fmt.Printf("ERROR receiving alerts of %s: %v\n", payload.GroupKey, err)
+			status := http.StatusForbidden
+			var reviewFailed *reviewFailedError
+			if errors.As(err, &reviewFailed) {
+				status = http.StatusInternalServerError
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
+	var errs []error
+	for i := range payload.Alerts {
+		if err := a.receive(r.Context(), &payload.Alerts[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		err := utilerrors.NewAggregate(errs)
+		fmt.Printf("ERROR receiving alerts of %s: %v\n", payload.GroupKey, err)
+		// Alertmanager retries the whole notification on server errors,
+		// which is fine since the alerts received already are
+		// deduplicated.
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// namespace returns the namespace of the Message of alert.
+func (a *AlertReceiver) namespace(alert *Alert) string {
+	if value := alert.Labels[a.NamespaceLabel]; value != "" && len(validation.IsDNS1123Label(value)) == 0 {
+		return value
+	}
+	return a.DefaultNamespace
+}
+
+// authorize checks that user may create the Message of alert if it is
+// firing, or mark it resolved.
+func (a *AlertReceiver) authorize(ctx context.Context, user *authenticationv1.UserInfo, alert *Alert) error {
+	verb := "create"
+	if alert.Status == messagev1.AlertStatusResolved {
+		verb = "patch"
+	}
+	return a.reviewer.authorize(ctx, user, messageAccess(verb, a.namespace(alert), ""))
+}
+
+// receive creates the Message of alert if it is firing, or marks it
+// resolved.
+func (a *AlertReceiver) receive(ctx context.Context, alert *Alert) error {
+	fingerprint := alertFingerprint(alert)
+	namespace := a.namespace(alert)
+	name := "alert-" + fingerprint + "-" + strconv.FormatInt(alert.StartsAt.Unix(), 10)
+	messages := a.client.MessageV1().Messages(namespace)
+
+	if alert.Status == messagev1.AlertStatusResolved {
+		message, err := messages.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// The alert fired before the receiver was set up, or
+			// its Message was deleted: there's nothing to resolve.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("getting Message %s/%s: %v", namespace, name, err)
+		}
+		if message.ObjectMeta.Labels[messagev1.AlertStatusLabel] == messagev1.AlertStatusResolved {
+			return nil
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels":          map[string]string{messagev1.AlertStatusLabel: messagev1.AlertStatusResolved},
+				"annotations":     map[string]string{messagev1.AlertResolvedAtAnnotation: alert.EndsAt.UTC().Format(time.RFC3339)},
+				"resourceVersion": message.ObjectMeta.ResourceVersion,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := messages.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return fmt.Errorf("resolving Message %s/%s: %v", namespace, name, err)
+		}
+		fmt.Printf("[ALERTMANAGER] Resolved Message %s/%s\n", namespace, name)
+		return nil
+	}
+
+	labels := map[string]string{}
+	for key, value := range alert.Labels {
+		// Alert labels are copied where they are valid Kubernetes labels,
+		// so that Subscriptions can filter on them.
+		if len(validation.IsQualifiedName(key)) == 0 && len(validation.IsValidLabelValue(value)) == 0 {
+			labels[key] = value
+		}
+	}
+	labels[messagev1.AlertFingerprintLabel] = fingerprint
+	labels[messagev1.AlertStatusLabel] = messagev1.AlertStatusFiring
+
+	message := &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: messagev1.MessageSpec{
+			Context: alertText(alert),
+			Urgent:  a.urgent(alert),
+			Topic:   a.Topic,
+		},
+	}
+	if a.ChannelName != "" {
+		message.Spec.ChannelRef = &messagev1.ChannelReference{Name: a.ChannelName}
+	}
+	_, err := messages.Create(ctx, message, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("creating Message %s/%s: %v", namespace, name, err)
+	}
+	fmt.Printf("[ALERTMANAGER] Created Message %s/%s\n", namespace, name)
+	return nil
+}
+
+func (a *AlertReceiver) urgent(alert *Alert) bool {
+	severity := alert.Labels["severity"]
+	for _, urgent := range a.UrgentSeverities {
+		if severity == urgent {
+			return true
+		}
+	}
+	return false
+}
+
+// alertText returns the summary of alert, falling back to its description
+// and then to its name.
+func alertText(alert *Alert) string {
+	for _, annotation := range []string{"summary", "description"} {
+		if text := alert.Annotations[annotation]; text != "" {
+			return text
+		}
+	}
+	return alert.Labels["alertname"]
+}
+
+// alertFingerprint returns the fingerprint of alert. Alertmanager sets it,
+// but senders that don't get one computed from the labels.
+func alertFingerprint(alert *Alert) string {
+	if fingerprint := strings.ToLower(alert.Fingerprint); fingerprint != "" && len(fingerprint) <= 32 {
+		if _, err := hex.DecodeString(fingerprint); err == nil {
+			return fingerprint
+		}
+	}
+	keys := make([]string, 0, len(alert.Labels))
+	for key := range alert.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\x00%s\x00", key, alert.Labels[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
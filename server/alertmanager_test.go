@@ -0,0 +1,148 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/pkg/client/clientset/versioned/fake"
+)
+
+func TestAlertReceiverAuthorization(t *testing.T) {
+	kubeClient := testKubeClient(
+		map[string]string{"token-am": "system:serviceaccount:monitoring:alertmanager"},
+		namespaceUsers(map[string]string{"system:serviceaccount:monitoring:alertmanager": "team-a"}),
+	)
+
+	tests := []struct {
+		name      string
+		token     string
+		namespace string
+		status    int
+	}{
+		{name: "no token", namespace: "team-a", status: http.StatusUnauthorized},
+		{name: "allowed namespace", token: "token-am", namespace: "team-a", status: http.StatusOK},
+		{name: "other namespace", token: "token-am", namespace: "kube-system", status: http.StatusForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			receiver := NewAlertReceiver(client, kubeClient)
+			body := `{"version":"4","status":"firing","alerts":[{"status":"firing",` +
+				`"labels":{"alertname":"DiskFull","namespace":"` + test.namespace + `"},` +
+				`"startsAt":"2026-10-19T10:00:00Z","fingerprint":"0123456789abcdef"}]}`
+			req := httptest.NewRequest(http.MethodPost, "/alertmanager", strings.NewReader(body))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, req)
+			if w.Code != test.status {
+				t.Fatalf("got status %d, want %d: %s", w.Code, test.status, w.Body)
+			}
+
+			messages, err := client.MessageV1().Messages(test.namespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			created := len(messages.Items) == 1 && messages.Items[0].ObjectMeta.Labels[messagev1.AlertStatusLabel] == messagev1.AlertStatusFiring
+			if created != (test.status == http.StatusOK) {
+				t.Errorf("got Messages %v in %s", messages.Items, test.namespace)
+			}
+		})
+	}
+}
+
+func TestAlertReceiverBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		// failCreate fails creating the Messages in that namespace.
+		failCreate string
+		// failReview fails reviewing the access to that namespace.
+		failReview   string
+		status       int
+		wantMessages int
+	}{
+		{name: "all allowed", namespaces: []string{"team-a", "team-a"}, status: http.StatusOK, wantMessages: 2},
+		{name: "one denied", namespaces: []string{"team-a", "kube-system"}, status: http.StatusForbidden},
+		{name: "one fails to be created", namespaces: []string{"team-a", "team-b"}, failCreate: "team-b", status: http.StatusInternalServerError, wantMessages: 1},
+		{name: "one denied, one fails to be created", namespaces: []string{"team-b", "kube-system"}, failCreate: "team-b", status: http.StatusForbidden},
+		{name: "access review fails", namespaces: []string{"team-a", "team-b"}, failReview: "team-b", status: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := testKubeClient(
+				map[string]string{"token-am": "system:serviceaccount:monitoring:alertmanager"},
+				func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+					return attributes.Namespace == "team-a" || attributes.Namespace == "team-b"
+				},
+			)
+			kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				if review.Spec.ResourceAttributes.Namespace == test.failReview {
+					return true, nil, errors.New("connection refused")
+				}
+				return false, nil, nil
+			})
+			client := fake.NewSimpleClientset()
+			client.PrependReactor("create", messagev1.MessageResourcePlural, func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetNamespace() == test.failCreate {
+					return true, nil, apierrors.NewServiceUnavailable("etcd is unavailable")
+				}
+				return false, nil, nil
+			})
+			receiver := NewAlertReceiver(client, kubeClient)
+
+			var alerts []string
+			for i, namespace := range test.namespaces {
+				alerts = append(alerts, `{"status":"firing",`+
+					`"labels":{"alertname":"DiskFull","namespace":"`+namespace+`"},`+
+					`"startsAt":"2026-10-19T10:00:00Z","fingerprint":"0123456789abcde`+strconv.Itoa(i)+`"}`)
+			}
+			body := `{"version":"4","status":"firing","alerts":[` + strings.Join(alerts, ",") + `]}`
+			req := httptest.NewRequest(http.MethodPost, "/alertmanager", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer token-am")
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, req)
+			if w.Code != test.status {
+				t.Fatalf("got status %d, want %d: %s", w.Code, test.status, w.Body)
+			}
+
+			messages, err := client.MessageV1().Messages(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(messages.Items) != test.wantMessages {
+				t.Errorf("got %d Messages, want %d", len(messages.Items), test.wantMessages)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// tokenReviewTTL is how long the result of a TokenReview is trusted.
+const tokenReviewTTL = time.Minute
+
+// accessReviewTTL is how long a SubjectAccessReview allowing a request is
+// trusted. It is shorter than tokenReviewTTL so that revoked permissions
+// take effect soon.
+const accessReviewTTL = 10 * time.Second
+
+// tokenReviewer authenticates requests by their bearer token, with a
+// TokenReview, and authorizes them with a SubjectAccessReview.
+type tokenReviewer struct {
+	client kubernetes.Interface
+	// queryToken accepts the token in the access_token query parameter,
+	// for the clients that can't set headers.
+	queryToken bool
+
+	lock    sync.Mutex
+	reviews map[[sha256.Size]byte]tokenReview
+	access  map[[sha256.Size]byte]time.Time
+}
+
+// tokenReview is a successful TokenReview.
+type tokenReview struct {
+	user       *authenticationv1.UserInfo
+	reviewedAt time.Time
+}
+
+func newTokenReviewer(client kubernetes.Interface, queryToken bool) *tokenReviewer {
+	return &tokenReviewer{
+		client:     client,
+		queryToken: queryToken,
+		reviews:    map[[sha256.Size]byte]tokenReview{},
+		access:     map[[sha256.Size]byte]time.Time{},
+	}
+}
+
+// authenticate returns the user r is authenticated as, replying 401 if it
+// isn't.
+func (t *tokenReviewer) authenticate(w http.ResponseWriter, r *http.Request) (*authenticationv1.UserInfo, bool) {
+	token, err := t.requestToken(r)
+	var user *authenticationv1.UserInfo
+	if err == nil {
+		user, err = t.review(r.Context(), token)
+	}
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return user, true
+}
+
+// requestToken returns the bearer token of r.
+func (t *tokenReviewer) requestToken(r *http.Request) (string, error) {
+	var token string
+	if t.queryToken {
+		token = r.URL.Query().Get("access_token")
+	}
+	if header := r.Header.Get("Authorization"); header != "" {
+		var ok bool
+		if token, ok = strings.CutPrefix(header, "Bearer "); !ok {
+			return "", fmt.Errorf("the Authorization header must hold a bearer token")
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("a bearer token is required")
+	}
+	return token, nil
+}
+
+// review returns the user token belongs to, checking it with a TokenReview
+// unless a review of the same token succeeded less than tokenReviewTTL ago.
+func (t *tokenReviewer) review(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	key := sha256.Sum256([]byte(token))
+	t.lock.Lock()
+	cached, ok := t.reviews[key]
+	t.lock.Unlock()
+	if ok && time.Since(cached.reviewedAt) < tokenReviewTTL {
+		return cached.user, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	review, err := t.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		fmt.Printf("ERROR reviewing a bearer token: %v\n", err)
+		return nil, fmt.Errorf("the token could not be reviewed")
+	}
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("the token is not valid")
+	}
+	user := &review.Status.User
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key, cached := range t.reviews {
+		if time.Since(cached.reviewedAt) >= tokenReviewTTL {
+			delete(t.reviews, key)
+		}
+	}
+	t.reviews[key] = tokenReview{user: user, reviewedAt: time.Now()}
+	return user, nil
+}
+
+// messageAccess returns the attributes of verb on the Messages in
+// namespace, or in all namespaces if namespace is empty.
+func messageAccess(verb, namespace, subresource string) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        verb,
+		Group:       messagev1.GroupName,
+		Version:     messagev1.SchemeGroupVersion.Version,
+		Resource:    messagev1.MessageResourcePlural,
+		Subresource: subresource,
+	}
+}
+
+// reviewFailedError is the error of an access that could not be reviewed,
+// as opposed to one that was denied. Retrying may help.
+type reviewFailedError struct {
+	user string
+}
+
+func (e *reviewFailedError) Error() string {
+	return fmt.Sprintf("the access of %s could not be reviewed", e.user)
+}
+
+// authorize checks that user may do what attributes describe with a
+// SubjectAccessReview, unless the same check succeeded less than
+// accessReviewTTL ago.
+func (t *tokenReviewer) authorize(ctx context.Context, user *authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) error {
+	key := sha256.Sum256([]byte(fmt.Sprintf("%q %q %q %v %+v", user.Username, user.UID, user.Groups, user.Extra, attributes)))
+	t.lock.Lock()
+	allowedAt, ok := t.access[key]
+	t.lock.Unlock()
+	if ok && time.Since(allowedAt) < accessReviewTTL {
+		return nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for name, values := range user.Extra {
+		extra[name] = authorizationv1.ExtraValue(values)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	review, err := t.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		fmt.Printf("ERROR reviewing the access of %s: %v\n", user.Username, err)
+		return &reviewFailedError{user: user.Username}
+	}
+	if !review.Status.Allowed {
+		resource := attributes.Resource
+		if attributes.Subresource != "" {
+			resource += "/" + attributes.Subresource
+		}
+		scope := "in all namespaces"
+		if attributes.Namespace != "" {
+			scope = "in namespace " + attributes.Namespace
+		}
+		return fmt.Errorf("%s may not %s %s %s", user.Username, attributes.Verb, resource, scope)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key, allowedAt := range t.access {
+		if time.Since(allowedAt) >= accessReviewTTL {
+			delete(t.access, key)
+		}
+	}
+	t.access[key] = time.Now()
+	return nil
+}
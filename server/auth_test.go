@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testKubeClient returns a clientset authenticating the tokens in users as
+// the user they map to, and allowing the SubjectAccessReviews allow allows.
+func testKubeClient(users map[string]string, allow func(user string, attributes *authorizationv1.ResourceAttributes) bool) *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if user, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: user, Groups: []string{"system:authenticated"}}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = allow(review.Spec.User, review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+	return client
+}
+
+// namespaceUsers allows users to do anything to the Messages of the
+// namespaces they map to, and "admin" to do anything anywhere.
+func namespaceUsers(namespaces map[string]string) func(string, *authorizationv1.ResourceAttributes) bool {
+	return func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		return user == "admin" || (attributes.Namespace != "" && namespaces[user] == attributes.Namespace)
+	}
+}
+
+func TestTokenReviewerAuthorize(t *testing.T) {
+	reviews := 0
+	client := testKubeClient(map[string]string{"token-a": "alice"}, func(user string, attributes *authorizationv1.ResourceAttributes) bool {
+		reviews++
+		return namespaceUsers(map[string]string{"alice": "team-a"})(user, attributes)
+	})
+	reviewer := newTokenReviewer(client, false)
+	ctx := context.Background()
+
+	if _, err := reviewer.review(ctx, "token-b"); err == nil {
+		t.Error("unknown token is authenticated")
+	}
+	user, err := reviewer.review(ctx, "token-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != "alice" {
+		t.Fatalf("got user %q, want alice", user.Username)
+	}
+
+	tests := []struct {
+		namespace string
+		wantErr   bool
+	}{
+		{namespace: "team-a"},
+		{namespace: "team-b", wantErr: true},
+		{namespace: "", wantErr: true},
+	}
+	for _, test := range tests {
+		err := reviewer.authorize(ctx, user, messageAccess("watch", test.namespace, ""))
+		if (err != nil) != test.wantErr {
+			t.Errorf("watching namespace %q: got error %v, want one: %v", test.namespace, err, test.wantErr)
+		}
+	}
+
+	// Allowed requests are not reviewed again right away, denied ones are.
+	reviews = 0
+	reviewer.authorize(ctx, user, messageAccess("watch", "team-a", ""))
+	reviewer.authorize(ctx, user, messageAccess("watch", "team-b", ""))
+	if reviews != 1 {
+		t.Errorf("got %d reviews, want 1", reviews)
+	}
+}
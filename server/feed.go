@@ -0,0 +1,269 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+const (
+	// feedBuffer is how many events a feed buffers before its client is
+	// considered to have fallen behind and is disconnected.
+	feedBuffer = 256
+	// feedKeepAlive is how often idle feeds are written to, so that
+	// proxies don't time them out.
+	feedKeepAlive = 30 * time.Second
+)
+
+// FeedEvent is an event of the feed, as JSON.
+type FeedEvent struct {
+	// ID is the Seq of the event. SSE clients resume after it with the
+	// Last-Event-ID header.
+	ID      uint64      `json:"id"`
+	Type    EventType   `json:"type"`
+	Message FeedMessage `json:"message"`
+}
+
+// FeedMessage is the Message of a FeedEvent.
+type FeedMessage struct {
+	Namespace         string                 `json:"namespace"`
+	Name              string                 `json:"name"`
+	UID               types.UID              `json:"uid"`
+	ResourceVersion   string                 `json:"resourceVersion"`
+	CreationTimestamp metav1.Time            `json:"creationTimestamp"`
+	Labels            map[string]string      `json:"labels,omitempty"`
+	Urgent            bool                   `json:"urgent"`
+	Text              string                 `json:"text"`
+	State             messagev1.MessageState `json:"state,omitempty"`
+}
+
+// FeedHandler serves a live feed of the Message events of a Hub, over
+// Server-Sent Events or, for WebSocket upgrade requests, over WebSocket.
+//
+// Clients authenticate with a bearer token, checked with a TokenReview.
+// Browsers can't set headers on EventSource and WebSocket requests, so the
+// access_token query parameter is accepted too. They need permission to list
+// and watch the Messages of the namespace they ask for, or of all namespaces
+// if they don't ask for one, checked with SubjectAccessReviews.
+//
+// The query parameters namespace and urgency ("urgent" or "not-urgent")
+// filter the events, and replay=N starts the feed with up to the last N
+// events the hub keeps. SSE clients reconnecting with Last-Event-ID get the
+// events they missed instead, as long as the hub still keeps them. Event ids
+// start over when the controller restarts.
+type FeedHandler struct {
+	hub      *Hub
+	reviewer *tokenReviewer
+	upgrader websocket.Upgrader
+}
+
+func NewFeedHandler(hub *Hub, client kubernetes.Interface) *FeedHandler {
+	return &FeedHandler{
+		hub:      hub,
+		reviewer: newTokenReviewer(client, true),
+	}
+}
+
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.reviewer.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter := &Filter{Namespace: query.Get("namespace")}
+	switch urgency := query.Get("urgency"); urgency {
+	case "":
+	case "urgent", "not-urgent":
+		urgent := urgency == "urgent"
+		filter.Urgent = &urgent
+	default:
+		http.Error(w, "urgency must be urgent or not-urgent", http.StatusBadRequest)
+		return
+	}
+	for _, verb := range []string{"list", "watch"} {
+		if err := h.reviewer.authorize(r.Context(), user, messageAccess(verb, filter.Namespace, "")); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	var after uint64
+	max := 0
+	if value := query.Get("replay"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "replay must be a number of events", http.StatusBadRequest)
+			return
+		}
+		max = n
+	}
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			http.Error(w, "Last-Event-ID must be the id of an event", http.StatusBadRequest)
+			return
+		}
+		after, max = id, math.MaxInt
+	}
+
+	history, subscription := h.hub.SubscribeHistory(feedBuffer, after, max)
+	defer subscription.Close()
+
+	if websocket.IsWebSocketUpgrade(r) {
+		h.serveWebSocket(w, r, filter, history, subscription)
+		return
+	}
+	h.serveSSE(w, r, filter, history, subscription)
+}
+
+func (h *FeedHandler) serveSSE(w http.ResponseWriter, r *http.Request, filter *Filter, history []Event, subscription *Subscription) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(event Event) error {
+		if !filter.Matches(event.Message) {
+			return nil
+		}
+		data, err := json.Marshal(toFeedEvent(event))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+		return err
+	}
+	for _, event := range history {
+		if err := write(event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(feedKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			// The client reconnects with Last-Event-ID after falling
+			// behind.
+			if !ok || write(event) != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (h *FeedHandler) serveWebSocket(w http.ResponseWriter, r *http.Request, filter *Filter, history []Event, subscription *Subscription) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already replied with the error.
+		return
+	}
+	defer conn.Close()
+
+	// The feed only writes, but reading is what processes the control
+	// messages and notices the client going away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event Event) error {
+		if !filter.Matches(event.Message) {
+			return nil
+		}
+		conn.SetWriteDeadline(time.Now().Add(feedKeepAlive))
+		return conn.WriteJSON(toFeedEvent(event))
+	}
+	for _, event := range history {
+		if err := write(event); err != nil {
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(feedKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(feedKeepAlive)); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, ErrOverflow.Error()),
+					time.Now().Add(time.Second))
+				return
+			}
+			if err := write(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func toFeedEvent(event Event) *FeedEvent {
+	message := event.Message
+	return &FeedEvent{
+		ID:   event.Seq,
+		Type: event.Type,
+		Message: FeedMessage{
+			Namespace:         message.ObjectMeta.Namespace,
+			Name:              message.ObjectMeta.Name,
+			UID:               message.ObjectMeta.UID,
+			ResourceVersion:   message.ObjectMeta.ResourceVersion,
+			CreationTimestamp: message.ObjectMeta.CreationTimestamp,
+			Labels:            message.ObjectMeta.Labels,
+			Urgent:            message.Spec.Urgent,
+			Text:              message.Text(),
+			State:             message.Status.State,
+		},
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFeedHandlerAuthorization(t *testing.T) {
+	client := testKubeClient(
+		map[string]string{"token-a": "alice", "token-admin": "admin"},
+		namespaceUsers(map[string]string{"alice": "team-a"}),
+	)
+	server := httptest.NewServer(NewFeedHandler(NewHub(10), client))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		token       string
+		query       string
+		lastEventID string
+		status      int
+	}{
+		{name: "no token", query: "?namespace=team-a", status: http.StatusUnauthorized},
+		{name: "invalid token", token: "token-x", query: "?namespace=team-a", status: http.StatusUnauthorized},
+		{name: "own namespace", token: "token-a", query: "?namespace=team-a", status: http.StatusOK},
+		{name: "query token", query: "?namespace=team-a&access_token=token-a", status: http.StatusOK},
+		{name: "other namespace", token: "token-a", query: "?namespace=team-b", status: http.StatusForbidden},
+		{name: "all namespaces", token: "token-a", status: http.StatusForbidden},
+		{name: "all namespaces with cluster-wide access", token: "token-admin", status: http.StatusOK},
+		{name: "invalid Last-Event-ID", token: "token-admin", lastEventID: "latest", status: http.StatusBadRequest},
+		{name: "out of range Last-Event-ID", token: "token-admin", lastEventID: "18446744073709551615", status: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+test.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			if test.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", test.lastEventID)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.status {
+				t.Errorf("got status %d, want %d", resp.StatusCode, test.status)
+			}
+		})
+	}
+}
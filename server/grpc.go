@@ -0,0 +1,240 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	broadcastv1 "github.com/yasker/example-crd/apis/broadcast/v1"
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	messageapplyv1 "github.com/yasker/example-crd/pkg/client/applyconfiguration/message/v1"
+	messageClientset "github.com/yasker/example-crd/pkg/client/clientset/versioned"
+	"github.com/yasker/example-crd/sink"
+)
+
+// FieldManager is the field manager the server applies the deliveries of
+// acknowledged Messages with.
+const FieldManager = "message-broadcast-server"
+
+// watchBuffer is how many events a Watch stream buffers before its
+// subscriber is considered to have fallen behind.
+const watchBuffer = 256
+
+// SubscriberSink returns the name the deliveries to a gRPC subscriber are
+// recorded under in the Message status.
+func SubscriberSink(subscriber string) string {
+	return "grpc/" + subscriber
+}
+
+// BroadcasterServer streams Messages over gRPC.
+//
+// Clients authenticate with a bearer token in the authorization metadata,
+// checked with a TokenReview, so the server must only be served over TLS.
+// Watching takes permission to list and watch the Messages of the namespace
+// watched, or of all namespaces if none is, and acknowledging takes
+// permission to patch the status of the Message, checked with
+// SubjectAccessReviews.
+type BroadcasterServer struct {
+	broadcastv1.UnimplementedBroadcasterServer
+
+	hub      *Hub
+	client   messageClientset.Interface
+	reviewer *tokenReviewer
+}
+
+func NewBroadcasterServer(hub *Hub, client messageClientset.Interface, kubeClient kubernetes.Interface) *BroadcasterServer {
+	return &BroadcasterServer{
+		hub:      hub,
+		client:   client,
+		reviewer: newTokenReviewer(kubeClient, false),
+	}
+}
+
+// authorize authenticates the caller of ctx by its bearer token and checks
+// that it may do everything attributes describe.
+func (s *BroadcasterServer) authorize(ctx context.Context, attributes ...authorizationv1.ResourceAttributes) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if values := md.Get("authorization"); len(values) != 0 {
+		token, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+	user, err := s.reviewer.review(ctx, token)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	for _, attributes := range attributes {
+		if err := s.reviewer.authorize(ctx, user, attributes); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *BroadcasterServer) Watch(req *broadcastv1.WatchRequest, stream broadcastv1.Broadcaster_WatchServer) error {
+	err := s.authorize(stream.Context(),
+		messageAccess("list", req.Namespace, ""),
+		messageAccess("watch", req.Namespace, ""))
+	if err != nil {
+		return err
+	}
+	if err := validateSubscriber(req.Subscriber); err != nil {
+		return err
+	}
+	filter := &Filter{Namespace: req.Namespace}
+	if req.LabelSelector != "" {
+		selector, err := labels.Parse(req.LabelSelector)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "label selector: %v", err)
+		}
+		filter.Selector = selector
+	}
+	switch req.Urgency {
+	case broadcastv1.Urgency_URGENCY_URGENT:
+		urgent := true
+		filter.Urgent = &urgent
+	case broadcastv1.Urgency_URGENCY_NOT_URGENT:
+		urgent := false
+		filter.Urgent = &urgent
+	}
+	var after uint64
+	resuming := req.ResumeToken != ""
+	if resuming {
+		var err error
+		if after, err = strconv.ParseUint(req.ResumeToken, 10, 64); err != nil {
+			return status.Errorf(codes.InvalidArgument, "resume token %q is not one of this server", req.ResumeToken)
+		}
+	}
+
+	messages, subscription := s.hub.Subscribe(watchBuffer)
+	defer subscription.Close()
+
+	send := func(eventType broadcastv1.MessageEvent_Type, message *messagev1.Message) error {
+		if !filter.Matches(message) || (resuming && resourceVersion(message) <= after) {
+			return nil
+		}
+		return stream.Send(&broadcastv1.MessageEvent{
+			Type:        eventType,
+			ResumeToken: message.ObjectMeta.ResourceVersion,
+			Message:     toProto(message, req.Subscriber),
+		})
+	}
+
+	initial := broadcastv1.MessageEvent_TYPE_ADDED
+	if resuming {
+		initial = broadcastv1.MessageEvent_TYPE_MODIFIED
+	}
+	for _, message := range messages {
+		if err := send(initial, message); err != nil {
+			return err
+		}
+	}
+	// Deletions carry the last resourceVersion of the Message, which the
+	// resume token may have passed already.
+	resuming = false
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, subscription.Err().Error()+", resume the stream")
+			}
+			eventType := broadcastv1.MessageEvent_TYPE_MODIFIED
+			switch event.Type {
+			case EventAdded:
+				eventType = broadcastv1.MessageEvent_TYPE_ADDED
+			case EventDeleted:
+				eventType = broadcastv1.MessageEvent_TYPE_DELETED
+			}
+			if err := send(eventType, event.Message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *BroadcasterServer) Ack(ctx context.Context, req *broadcastv1.AckRequest) (*broadcastv1.AckResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if err := s.authorize(ctx, messageAccess("patch", req.Namespace, "status")); err != nil {
+		return nil, err
+	}
+	if err := validateSubscriber(req.Subscriber); err != nil {
+		return nil, err
+	}
+	message := s.hub.Get(req.Namespace, req.Name)
+	if message == nil {
+		return nil, status.Errorf(codes.NotFound, "Message %s/%s not found", req.Namespace, req.Name)
+	}
+	if req.Uid != "" && req.Uid != string(message.ObjectMeta.UID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Message %s/%s has UID %s, not %s",
+			req.Namespace, req.Name, message.ObjectMeta.UID, req.Uid)
+	}
+
+	_, err := s.client.MessageV1().Messages(req.Namespace).ApplyStatus(ctx,
+		messageapplyv1.Message(req.Name, req.Namespace).
+			WithStatus(messageapplyv1.MessageStatus().
+				WithDeliveries(messageapplyv1.MessageDelivery().
+					WithSink(SubscriberSink(req.Subscriber)).
+					WithState(messagev1.DeliveryStateDelivered).
+					WithDeliveredAt(metav1.Now()))),
+		metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "recording the delivery: %v", err)
+	}
+	return &broadcastv1.AckResponse{}, nil
+}
+
+func validateSubscriber(subscriber string) error {
+	if errs := validation.IsDNS1123Subdomain(subscriber); len(errs) != 0 {
+		return status.Errorf(codes.InvalidArgument, "subscriber %q: %s", subscriber, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func toProto(message *messagev1.Message, subscriber string) *broadcastv1.Message {
+	return &broadcastv1.Message{
+		Namespace:       message.ObjectMeta.Namespace,
+		Name:            message.ObjectMeta.Name,
+		Uid:             string(message.ObjectMeta.UID),
+		ResourceVersion: message.ObjectMeta.ResourceVersion,
+		Generation:      message.ObjectMeta.Generation,
+		CreationTime:    timestamppb.New(message.ObjectMeta.CreationTimestamp.Time),
+		Labels:          message.ObjectMeta.Labels,
+		Urgent:          message.Spec.Urgent,
+		Text:            message.Text(),
+		State:           string(message.Status.State),
+		DeliveryId:      sink.NewDelivery(message, SubscriberSink(subscriber), "").ID,
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	broadcastv1 "github.com/yasker/example-crd/apis/broadcast/v1"
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+	"github.com/yasker/example-crd/pkg/client/clientset/versioned/fake"
+)
+
+// testBroadcasterClient serves a BroadcasterServer of hub in process and
+// returns a client of it.
+func testBroadcasterClient(t *testing.T, hub *Hub) broadcastv1.BroadcasterClient {
+	kubeClient := testKubeClient(
+		map[string]string{"token-a": "alice", "token-admin": "admin"},
+		namespaceUsers(map[string]string{"alice": "team-a"}),
+	)
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	broadcastv1.RegisterBroadcasterServer(server, NewBroadcasterServer(hub, fake.NewSimpleClientset(), kubeClient))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return broadcastv1.NewBroadcasterClient(conn)
+}
+
+func TestBroadcasterServerAuthorization(t *testing.T) {
+	hub := NewHub(10)
+	for _, namespace := range []string{"team-a", "team-b"} {
+		hub.OnAdd(&messagev1.Message{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "disk-full", ResourceVersion: "1"},
+		}, true)
+	}
+	client := testBroadcasterClient(t, hub)
+
+	tests := []struct {
+		name      string
+		token     string
+		namespace string
+		want      codes.Code
+	}{
+		{name: "no token", namespace: "team-a", want: codes.Unauthenticated},
+		{name: "invalid token", token: "token-x", namespace: "team-a", want: codes.Unauthenticated},
+		{name: "own namespace", token: "token-a", namespace: "team-a", want: codes.OK},
+		{name: "other namespace", token: "token-a", namespace: "team-b", want: codes.PermissionDenied},
+		{name: "all namespaces", token: "token-a", want: codes.PermissionDenied},
+		{name: "all namespaces with cluster-wide access", token: "token-admin", want: codes.OK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if test.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+test.token)
+			}
+
+			stream, err := client.Watch(ctx, &broadcastv1.WatchRequest{Subscriber: "pager", Namespace: test.namespace})
+			if err == nil {
+				var event *broadcastv1.MessageEvent
+				if event, err = stream.Recv(); err == nil && test.namespace != "" && event.Message.Namespace != test.namespace {
+					t.Errorf("got a Message of namespace %s", event.Message.Namespace)
+				}
+			}
+			if got := status.Code(err); got != test.want {
+				t.Errorf("Watch: got %v (%v), want %v", got, err, test.want)
+			}
+
+			// Acknowledging the Message of another namespace is
+			// denied before the Message is looked for.
+			_, err = client.Ack(ctx, &broadcastv1.AckRequest{Subscriber: "pager", Namespace: "team-b", Name: "disk-full"})
+			if got := status.Code(err); test.want == codes.PermissionDenied && got != codes.PermissionDenied {
+				t.Errorf("Ack: got %v (%v), want %v", got, err, codes.PermissionDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server serves Messages to the consumers that would rather
+// subscribe to them than have the controller push them to a sink.
+package server
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	EventAdded    EventType = "Added"
+	EventModified EventType = "Modified"
+	EventDeleted  EventType = "Deleted"
+)
+
+// Event is a change of a Message.
+type Event struct {
+	// Seq numbers the events of a Hub in the order they were published,
+	// starting from 1. The hub does not persist it, so it starts over when
+	// the process restarts.
+	Seq     uint64
+	Type    EventType
+	Message *messagev1.Message
+}
+
+// ErrOverflow is the error of a Subscription closed because its subscriber
+// fell behind.
+var ErrOverflow = errors.New("subscriber fell behind the Message events")
+
+// Hub fans the Message events of the controller's informer out to the
+// subscribers, so that they don't each need a watch of their own. Add it to
+// the EventHandlers of the controller.
+//
+// The hub keeps the last events it published, so that subscribers can
+// replay them.
+type Hub struct {
+	lock        sync.Mutex
+	messages    map[string]*messagev1.Message
+	subscribers map[*Subscription]struct{}
+	seq         uint64
+	// history is a ring of the last events, history[seq%len(history)]
+	// being the event seq.
+	history []Event
+}
+
+// NewHub returns a hub keeping the last history events. history must not be
+// negative.
+func NewHub(history int) *Hub {
+	return &Hub{
+		messages:    map[string]*messagev1.Message{},
+		subscribers: map[*Subscription]struct{}{},
+		history:     make([]Event, history),
+	}
+}
+
+// Subscription receives the events published after it was created, until
+// it is closed.
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+	err    error
+}
+
+// Events returns the channel of the events. It is closed when the
+// subscription is, after which Err tells why.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns ErrOverflow if the subscription was closed because it fell
+// behind, nil otherwise.
+func (s *Subscription) Err() error {
+	s.hub.lock.Lock()
+	defer s.hub.lock.Unlock()
+	return s.err
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.hub.lock.Lock()
+	defer s.hub.lock.Unlock()
+	s.hub.unsubscribe(s)
+}
+
+// Subscribe returns a subscription buffering up to buffer events, and the
+// Messages as of when it was created, oldest resourceVersion first. No
+// change is missed nor seen twice between the two.
+func (h *Hub) Subscribe(buffer int) ([]*messagev1.Message, *Subscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	messages := make([]*messagev1.Message, 0, len(h.messages))
+	for _, message := range h.messages {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return resourceVersion(messages[i]) < resourceVersion(messages[j])
+	})
+
+	s := &Subscription{hub: h, events: make(chan Event, buffer)}
+	h.subscribers[s] = struct{}{}
+	return messages, s
+}
+
+// SubscribeHistory returns a subscription buffering up to buffer events, and
+// the last events the hub keeps that were published after the event after,
+// at most max of them, oldest first. No event is missed nor seen twice
+// between the two. An after past the last event, such as the Seq of an event
+// of a previous process, replays none.
+func (h *Hub) SubscribeHistory(buffer int, after uint64, max int) ([]Event, *Subscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if max > len(h.history) {
+		max = len(h.history)
+	}
+	if after > h.seq {
+		after = h.seq
+	}
+	first := after + 1
+	if h.seq >= uint64(max) && first <= h.seq-uint64(max) {
+		first = h.seq - uint64(max) + 1
+	}
+	var events []Event
+	for seq := first; seq <= h.seq; seq++ {
+		events = append(events, h.history[seq%uint64(len(h.history))])
+	}
+
+	s := &Subscription{hub: h, events: make(chan Event, buffer)}
+	h.subscribers[s] = struct{}{}
+	return events, s
+}
+
+// Get returns the Message namespace/name, or nil if there is none.
+func (h *Hub) Get(namespace, name string) *messagev1.Message {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.messages[namespace+"/"+name]
+}
+
+func (h *Hub) OnAdd(obj interface{}, isInInitialList bool) {
+	if message, ok := obj.(*messagev1.Message); ok {
+		h.publish(EventAdded, message)
+	}
+}
+
+func (h *Hub) OnUpdate(oldObj, newObj interface{}) {
+	oldMessage, ok := oldObj.(*messagev1.Message)
+	if !ok {
+		return
+	}
+	newMessage, ok := newObj.(*messagev1.Message)
+	// Resyncs call OnUpdate with the same Message, which is no change.
+	if !ok || oldMessage.ObjectMeta.ResourceVersion == newMessage.ObjectMeta.ResourceVersion {
+		return
+	}
+	h.publish(EventModified, newMessage)
+}
+
+func (h *Hub) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if message, ok := obj.(*messagev1.Message); ok {
+		h.publish(EventDeleted, message)
+	}
+}
+
+func (h *Hub) publish(eventType EventType, message *messagev1.Message) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	key := message.ObjectMeta.Namespace + "/" + message.ObjectMeta.Name
+	if eventType == EventDeleted {
+		delete(h.messages, key)
+	} else {
+		h.messages[key] = message
+	}
+
+	h.seq++
+	event := Event{Seq: h.seq, Type: eventType, Message: message}
+	if len(h.history) != 0 {
+		h.history[h.seq%uint64(len(h.history))] = event
+	}
+	for s := range h.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			s.err = ErrOverflow
+			h.unsubscribe(s)
+		}
+	}
+}
+
+// unsubscribe closes s. h.lock must be held.
+func (h *Hub) unsubscribe(s *Subscription) {
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.events)
+	}
+}
+
+// resourceVersion returns the resourceVersion of message as a number, for
+// ordering and resuming. The API server only promises resourceVersions to
+// be opaque, but etcd's are increasing numbers.
+func resourceVersion(message *messagev1.Message) uint64 {
+	rv, _ := strconv.ParseUint(message.ObjectMeta.ResourceVersion, 10, 64)
+	return rv
+}
+
+// Filter selects Messages.
+type Filter struct {
+	// Namespace selects the Messages of a namespace. Empty selects all.
+	Namespace string
+	// Selector selects Messages by their labels. Nil selects all.
+	Selector labels.Selector
+	// Urgent, if set, selects the Messages whose spec.urgent is the same.
+	Urgent *bool
+}
+
+// Matches tells whether f selects message.
+func (f *Filter) Matches(message *messagev1.Message) bool {
+	if f.Namespace != "" && message.ObjectMeta.Namespace != f.Namespace {
+		return false
+	}
+	if f.Selector != nil && !f.Selector.Matches(labels.Set(message.ObjectMeta.Labels)) {
+		return false
+	}
+	return f.Urgent == nil || message.Spec.Urgent == *f.Urgent
+}
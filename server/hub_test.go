@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+func TestSubscribeHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		history int
+		after   uint64
+		max     int
+		wantSeq []uint64
+	}{
+		{name: "from the start", history: 3, after: 0, max: math.MaxInt, wantSeq: []uint64{3, 4, 5}},
+		{name: "after an event kept", history: 3, after: 3, max: math.MaxInt, wantSeq: []uint64{4, 5}},
+		{name: "after the last event", history: 3, after: 5, max: math.MaxInt},
+		{name: "at most max", history: 3, after: 0, max: 1, wantSeq: []uint64{5}},
+		{name: "all events kept", history: 10, after: 0, max: math.MaxInt, wantSeq: []uint64{1, 2, 3, 4, 5}},
+		{name: "past the last event", history: 10, after: 42, max: math.MaxInt},
+		{name: "out of range", history: 10, after: math.MaxUint64, max: math.MaxInt},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := NewHub(test.history)
+			for i := 1; i <= 5; i++ {
+				hub.OnAdd(&messagev1.Message{ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "team-a",
+					Name:            fmt.Sprintf("disk-full-%d", i),
+					ResourceVersion: fmt.Sprint(i),
+				}}, false)
+			}
+
+			events, subscription := hub.SubscribeHistory(1, test.after, test.max)
+			defer subscription.Close()
+
+			var seqs []uint64
+			for _, event := range events {
+				if event.Message == nil {
+					t.Fatalf("got event %d without a Message", event.Seq)
+				}
+				seqs = append(seqs, event.Seq)
+			}
+			if fmt.Sprint(seqs) != fmt.Sprint(test.wantSeq) {
+				t.Errorf("got events %v, want %v", seqs, test.wantSeq)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CloudEventsSpecVersion is the version of the CloudEvents specification
+// the events follow.
+const CloudEventsSpecVersion = "1.0"
+
+// CloudEventsContentType is the content type of structured mode events.
+const CloudEventsContentType = "application/cloudevents+json"
+
+// CloudEvent is a delivery as a CloudEvents event, in the JSON format.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	// DeliveryID is the ID of the delivery, as an extension attribute.
+	DeliveryID string          `json:"deliveryid"`
+	Data       *CloudEventData `json:"data"`
+}
+
+// CloudEventData is the data of a CloudEvent.
+type CloudEventData struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	UID       types.UID         `json:"uid"`
+	Urgent    bool              `json:"urgent"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// NewCloudEvent returns delivery as a CloudEvent. The source is the path of
+// the Message and the ID is its UID and generation, so that every generation
+// is an event of its own. Deliveries of a later stage, such as escalations
+// and status changes, append the stage to the ID, since they are events of
+// their own too. The type is the event the delivery announces.
+func NewCloudEvent(delivery *Delivery) *CloudEvent {
+	message := delivery.Message
+	id := string(message.ObjectMeta.UID) + "." + strconv.FormatInt(message.ObjectMeta.Generation, 10)
+	if delivery.Stage != "" {
+		id += "." + delivery.Stage
+	}
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              id,
+		Source:          "/namespaces/" + message.ObjectMeta.Namespace + "/messages/" + message.ObjectMeta.Name,
+		Type:            delivery.Event,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DeliveryID:      delivery.ID,
+		Data: &CloudEventData{
+			Namespace: message.ObjectMeta.Namespace,
+			Name:      message.ObjectMeta.Name,
+			UID:       message.ObjectMeta.UID,
+			Urgent:    message.Spec.Urgent,
+			Message:   message.Text(),
+			Labels:    message.ObjectMeta.Labels,
+		},
+	}
+}
+
+// Attributes returns the attributes of e besides its data and data content
+// type, as binary mode carries them.
+func (e *CloudEvent) Attributes() map[string]string {
+	return map[string]string{
+		"specversion": e.SpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+		"time":        e.Time.Format(time.RFC3339Nano),
+		"deliveryid":  e.DeliveryID,
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// EventSink is a Sink that is also told about the status changes of the
+// Messages it delivers, not just their broadcasts and escalations.
+type EventSink interface {
+	Sink
+	// DeliversEvents tells whether the sink wants the Events of status
+	// changes delivered, such as because it encodes deliveries as
+	// CloudEvents.
+	DeliversEvents() bool
+}
+
+// stateEvents are the events announcing that a Message entered a state,
+// besides EventCreated. Broadcasted Messages are announced by their
+// deliveries.
+var stateEvents = map[messagev1.MessageState]string{
+	messagev1.MessageStateScheduled:  EventScheduled,
+	messagev1.MessageStateSuppressed: EventSuppressed,
+	messagev1.MessageStateFailed:     EventFailed,
+}
+
+// Events returns the events announcing the change of the status of a
+// Message from old to new, in the order they happened. The broadcast and
+// escalations of the Message are not among them, since deliveries announce
+// those themselves.
+func Events(old, new *messagev1.MessageStatus) []string {
+	var events []string
+	if old.State == "" && new.State != "" {
+		events = append(events, EventCreated)
+	}
+	if event, ok := stateEvents[new.State]; ok && new.State != old.State {
+		events = append(events, event)
+	}
+	if acknowledged(new) && !acknowledged(old) {
+		events = append(events, EventAcknowledged)
+	}
+	return events
+}
+
+func acknowledged(status *messagev1.MessageStatus) bool {
+	return status.Acknowledgement != nil && status.Acknowledgement.State == messagev1.AcknowledgementStateAcknowledged
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Formats deliveries are encoded in, for the sinks with a format setting.
+const (
+	// FormatEnvelope is the JSON Envelope.
+	FormatEnvelope = "envelope"
+	// FormatCloudEventsStructured is a CloudEvents 1.0 event in structured
+	// mode: the whole event is the JSON body.
+	FormatCloudEventsStructured = "cloudevents-structured"
+	// FormatCloudEventsBinary is a CloudEvents 1.0 event in binary mode:
+	// the attributes are headers and the data is the body.
+	FormatCloudEventsBinary = "cloudevents-binary"
+)
+
+func validateFormat(format string) error {
+	switch format {
+	case FormatEnvelope, FormatCloudEventsStructured, FormatCloudEventsBinary:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, must be one of %s, %s or %s",
+		format, FormatEnvelope, FormatCloudEventsStructured, FormatCloudEventsBinary)
+}
+
+// EnvelopeVersion is the version of Envelope. It changes when fields are
+// removed or change meaning.
+const EnvelopeVersion = "v1"
+
+// Envelope is the JSON a delivery is encoded as in FormatEnvelope.
+type Envelope struct {
+	Version    string            `json:"version"`
+	DeliveryID string            `json:"deliveryId"`
+	Namespace  string            `json:"namespace"`
+	Name       string            `json:"name"`
+	UID        types.UID         `json:"uid"`
+	Urgent     bool              `json:"urgent"`
+	Message    string            `json:"message"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// encoded is a delivery encoded in a format.
+type encoded struct {
+	body        []byte
+	contentType string
+	// attributes are the CloudEvents attributes to send as headers, in
+	// binary mode.
+	attributes map[string]string
+}
+
+// encode encodes delivery in format.
+func encode(format string, delivery *Delivery) (*encoded, error) {
+	switch format {
+	case FormatCloudEventsStructured:
+		body, err := json.Marshal(NewCloudEvent(delivery))
+		if err != nil {
+			return nil, err
+		}
+		return &encoded{body: body, contentType: CloudEventsContentType}, nil
+	case FormatCloudEventsBinary:
+		event := NewCloudEvent(delivery)
+		body, err := json.Marshal(event.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &encoded{body: body, contentType: event.DataContentType, attributes: event.Attributes()}, nil
+	}
+
+	message := delivery.Message
+	body, err := json.Marshal(&Envelope{
+		Version:    EnvelopeVersion,
+		DeliveryID: delivery.ID,
+		Namespace:  message.ObjectMeta.Namespace,
+		Name:       message.ObjectMeta.Name,
+		UID:        message.ObjectMeta.UID,
+		Urgent:     message.Spec.Urgent,
+		Message:    message.Text(),
+		Labels:     message.ObjectMeta.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &encoded{body: body, contentType: "application/json"}, nil
+}
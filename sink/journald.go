@@ -0,0 +1,144 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Settings of the Journald sink.
+const (
+	// JournaldSocket is the path of the native socket of the journal.
+	// Defaults to /run/systemd/journal/socket.
+	JournaldSocket = "socket"
+	// JournaldIdentifier is the SYSLOG_IDENTIFIER of the entries.
+	// Defaults to DefaultIdentifier.
+	JournaldIdentifier = "identifier"
+)
+
+// Fields of the journal entries besides MESSAGE, PRIORITY and
+// SYSLOG_IDENTIFIER.
+const (
+	JournaldFieldNamespace = "MESSAGE_NAMESPACE"
+	JournaldFieldName      = "MESSAGE_NAME"
+	JournaldFieldUID       = "MESSAGE_UID"
+	JournaldFieldUrgent    = "MESSAGE_URGENT"
+	JournaldFieldEvent     = "MESSAGE_EVENT"
+	JournaldFieldDelivery  = "MESSAGE_DELIVERY_ID"
+)
+
+// JournaldSink broadcasts Messages by writing them to the systemd journal
+// through its native protocol, with the Message in structured fields.
+type JournaldSink struct {
+	name       string
+	socket     string
+	identifier string
+
+	lock sync.Mutex
+	conn *net.UnixConn
+}
+
+func NewJournaldSink(name string, settings map[string]string) (*JournaldSink, error) {
+	s := &JournaldSink{
+		name:       name,
+		socket:     "/run/systemd/journal/socket",
+		identifier: DefaultIdentifier,
+	}
+	if socket, ok := settings[JournaldSocket]; ok {
+		s.socket = socket
+	}
+	if identifier, ok := settings[JournaldIdentifier]; ok {
+		s.identifier = identifier
+	}
+	return s, nil
+}
+
+func (s *JournaldSink) Name() string {
+	return s.name
+}
+
+func (s *JournaldSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	message := delivery.Message
+	var entry bytes.Buffer
+	for _, field := range []struct{ name, value string }{
+		{"MESSAGE", message.Text()},
+		{"PRIORITY", strconv.Itoa(severity(delivery))},
+		{"SYSLOG_IDENTIFIER", s.identifier},
+		{JournaldFieldNamespace, message.ObjectMeta.Namespace},
+		{JournaldFieldName, message.ObjectMeta.Name},
+		{JournaldFieldUID, string(message.ObjectMeta.UID)},
+		{JournaldFieldUrgent, strconv.FormatBool(message.Spec.Urgent)},
+		{JournaldFieldEvent, delivery.Event},
+		{JournaldFieldDelivery, delivery.ID},
+	} {
+		writeJournalField(&entry, field.name, field.value)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: s.socket, Net: "unixgram"})
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	if _, err := s.conn.Write(entry.Bytes()); err != nil {
+		// The journal may have been restarted, so the next delivery
+		// connects again.
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("writing to the journal: %v", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the journal, if any.
+func (s *JournaldSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// writeJournalField writes the field name of value to entry in the native
+// protocol of the journal: values holding a newline are written as their
+// little endian 64 bit length followed by the value.
+func writeJournalField(entry *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(entry, "%s=%s\n", name, value)
+		return
+	}
+	entry.WriteString(name)
+	entry.WriteByte('\n')
+	binary.Write(entry, binary.LittleEndian, uint64(len(value)))
+	entry.WriteString(value)
+	entry.WriteByte('\n')
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// parseJournalEntry parses an entry of the native protocol of the journal.
+func parseJournalEntry(t *testing.T, entry []byte) map[string]string {
+	fields := map[string]string{}
+	for len(entry) > 0 {
+		line, rest, ok := bytes.Cut(entry, []byte("\n"))
+		if !ok {
+			t.Fatalf("entry ends without a newline: %q", entry)
+		}
+		if name, value, ok := bytes.Cut(line, []byte("=")); ok {
+			fields[string(name)] = string(value)
+			entry = rest
+			continue
+		}
+		if len(rest) < 8 {
+			t.Fatalf("field %s has no length", line)
+		}
+		n := binary.LittleEndian.Uint64(rest)
+		rest = rest[8:]
+		if uint64(len(rest)) < n+1 || rest[n] != '\n' {
+			t.Fatalf("field %s is shorter than its length %d", line, n)
+		}
+		fields[string(line)] = string(rest[:n])
+		entry = rest[n+1:]
+	}
+	return fields
+}
+
+func TestJournaldSinkDeliver(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	s, err := NewJournaldSink("journal", map[string]string{
+		JournaldSocket:     socket,
+		JournaldIdentifier: "broadcaster",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	message := testMessage()
+	message.Spec.Context = "disk /var is 95% full\non db-0"
+	delivery := NewDelivery(message, s.Name(), "")
+	if err := s.Deliver(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 64*1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := parseJournalEntry(t, buf[:n])
+	for name, want := range map[string]string{
+		"MESSAGE":              "disk /var is 95% full\non db-0",
+		"PRIORITY":             "2",
+		"SYSLOG_IDENTIFIER":    "broadcaster",
+		JournaldFieldNamespace: "team-a",
+		JournaldFieldName:      "disk-full",
+		JournaldFieldUrgent:    "true",
+		JournaldFieldEvent:     EventBroadcasted,
+		JournaldFieldDelivery:  delivery.ID,
+	} {
+		if got := fields[name]; got != want {
+			t.Errorf("got field %s %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Settings of the Kafka sink.
+const (
+	// KafkaBrokers is a comma separated list of the host:port of the
+	// brokers to bootstrap from.
+	KafkaBrokers = "brokers"
+	// KafkaTopic is the topic deliveries are produced to.
+	KafkaTopic = "topic"
+	// KafkaPartitionKey is what the records are keyed by, which picks
+	// their partition: "name", the default, keys them by the namespace and
+	// name of the Message, and "orderingKey" by its namespace and ordering
+	// key, falling back to its name, so that ordered Messages stay in
+	// order on the topic.
+	KafkaPartitionKey = "partitionKey"
+	// KafkaRequiredAcks is how many replicas must acknowledge a record
+	// before the delivery succeeds: "all", the default, or "one".
+	KafkaRequiredAcks = "requiredAcks"
+	// KafkaTLS enables TLS when "true".
+	KafkaTLS = "tls"
+	// KafkaCABundle is the PEM encoded CA certificates the broker
+	// certificates are verified with, instead of the system's. It enables
+	// TLS.
+	KafkaCABundle = "caBundle"
+	// KafkaTimeout is the timeout of a delivery. Defaults to 10 seconds.
+	KafkaTimeout = "timeout"
+	// KafkaFormat is how deliveries are encoded, one of the Format
+	// constants. Defaults to FormatEnvelope. The CloudEvents attributes of
+	// binary mode are sent as ce_ headers.
+	KafkaFormat = "format"
+)
+
+// Values of KafkaPartitionKey.
+const (
+	KafkaPartitionKeyName        = "name"
+	KafkaPartitionKeyOrderingKey = "orderingKey"
+)
+
+// KafkaSink broadcasts Messages by producing them to a Kafka topic.
+type KafkaSink struct {
+	name         string
+	format       string
+	partitionKey string
+	timeout      time.Duration
+	writer       *kafka.Writer
+}
+
+func NewKafkaSink(name string, settings map[string]string) (*KafkaSink, error) {
+	s := &KafkaSink{
+		name:         name,
+		format:       FormatEnvelope,
+		partitionKey: KafkaPartitionKeyName,
+		timeout:      10 * time.Second,
+	}
+
+	var brokers []string
+	for _, broker := range strings.Split(settings[KafkaBrokers], ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("setting %s is required", KafkaBrokers)
+	}
+	topic := settings[KafkaTopic]
+	if topic == "" {
+		return nil, fmt.Errorf("setting %s is required", KafkaTopic)
+	}
+
+	if key, ok := settings[KafkaPartitionKey]; ok {
+		if key != KafkaPartitionKeyName && key != KafkaPartitionKeyOrderingKey {
+			return nil, fmt.Errorf("setting %s must be %s or %s, got %q",
+				KafkaPartitionKey, KafkaPartitionKeyName, KafkaPartitionKeyOrderingKey, key)
+		}
+		s.partitionKey = key
+	}
+	acks := kafka.RequireAll
+	if value, ok := settings[KafkaRequiredAcks]; ok {
+		switch value {
+		case "all":
+		case "one":
+			acks = kafka.RequireOne
+		default:
+			return nil, fmt.Errorf("setting %s must be all or one, got %q", KafkaRequiredAcks, value)
+		}
+	}
+	if format, ok := settings[KafkaFormat]; ok {
+		if err := validateFormat(format); err != nil {
+			return nil, fmt.Errorf("setting %s: %v", KafkaFormat, err)
+		}
+		s.format = format
+	}
+	if value, ok := settings[KafkaTimeout]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("setting %s must be a positive duration, got %q", KafkaTimeout, value)
+		}
+		s.timeout = timeout
+	}
+
+	transport := &kafka.Transport{
+		DialTimeout: s.timeout,
+		ClientID:    DefaultIdentifier,
+	}
+	if bundle, ok := settings[KafkaCABundle]; ok {
+		pool, err := certPool(KafkaCABundle, bundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	} else if settings[KafkaTLS] == "true" {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	s.writer = &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.Hash{},
+		// The controller retries failed deliveries itself.
+		MaxAttempts:  1,
+		RequiredAcks: acks,
+		// Deliveries are produced one at a time by each worker, so
+		// waiting for a batch to fill up only delays them.
+		BatchTimeout: time.Millisecond,
+		WriteTimeout: s.timeout,
+		ReadTimeout:  s.timeout,
+		Transport:    transport,
+	}
+	return s, nil
+}
+
+func (s *KafkaSink) Name() string {
+	return s.name
+}
+
+// DeliversEvents tells whether deliveries are encoded as CloudEvents, whose
+// consumers want every status change of a Message.
+func (s *KafkaSink) DeliversEvents() bool {
+	return s.format != FormatEnvelope
+}
+
+// Deliver produces delivery and returns once the brokers acknowledged it as
+// KafkaRequiredAcks asks.
+func (s *KafkaSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	encoded, err := encode(s.format, delivery)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+
+	message := delivery.Message
+	key := message.ObjectMeta.Namespace + "/" + message.ObjectMeta.Name
+	if s.partitionKey == KafkaPartitionKeyOrderingKey && message.Spec.OrderingKey != "" {
+		key = message.ObjectMeta.Namespace + "/" + message.Spec.OrderingKey
+	}
+	record := kafka.Message{
+		Key:   []byte(key),
+		Value: encoded.body,
+		Headers: []kafka.Header{
+			{Key: "content-type", Value: []byte(encoded.contentType)},
+			{Key: DeliveryHeader, Value: []byte(delivery.ID)},
+		},
+	}
+	for name, value := range encoded.attributes {
+		record.Headers = append(record.Headers, kafka.Header{Key: "ce_" + name, Value: []byte(value)})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return kafkaError(s.writer.WriteMessages(ctx, record))
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+// kafkaError marks the errors of the brokers retrying won't fix as
+// permanent.
+func kafkaError(err error) error {
+	var writeErrors kafka.WriteErrors
+	if errors.As(err, &writeErrors) && len(writeErrors) == 1 && writeErrors[0] != nil {
+		err = writeErrors[0]
+	}
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) && !kafkaErr.Temporary() {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
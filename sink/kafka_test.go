@@ -0,0 +1,232 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+)
+
+// kafkaRecord is a record produced to fakeKafkaBroker.
+type kafkaRecord struct {
+	topic   string
+	key     string
+	value   string
+	headers map[string]string
+}
+
+// fakeKafkaBroker is a single Kafka broker leading the one partition of
+// every topic, listing just the "messages" topic. It answers just the requests producing takes, failing
+// produce requests with errorCode if it isn't zero.
+type fakeKafkaBroker struct {
+	listener  net.Listener
+	errorCode int16
+
+	lock    sync.Mutex
+	records []kafkaRecord
+}
+
+func newFakeKafkaBroker(t *testing.T, errorCode int16) *fakeKafkaBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := &fakeKafkaBroker{listener: listener, errorCode: errorCode}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go broker.handle(t, conn)
+		}
+	}()
+	return broker
+}
+
+func (b *fakeKafkaBroker) handle(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		version, correlationID, _, request, err := protocol.ReadRequest(r)
+		if err != nil {
+			if err != io.EOF {
+				t.Logf("reading Kafka request: %v", err)
+			}
+			return
+		}
+		var response protocol.Message
+		switch request := request.(type) {
+		case *apiversions.Request:
+			response = &apiversions.Response{ApiKeys: []apiversions.ApiKeyResponse{
+				{ApiKey: int16(protocol.Produce), MinVersion: 3, MaxVersion: 7},
+				{ApiKey: int16(protocol.Metadata), MinVersion: 1, MaxVersion: 8},
+				{ApiKey: int16(protocol.ApiVersions), MinVersion: 0, MaxVersion: 2},
+			}}
+		case *metadata.Request:
+			host, port, _ := net.SplitHostPort(b.listener.Addr().String())
+			portNumber, _ := strconv.Atoi(port)
+			metadataResponse := &metadata.Response{
+				Brokers:      []metadata.ResponseBroker{{NodeID: 1, Host: host, Port: int32(portNumber)}},
+				ControllerID: 1,
+			}
+			topics := request.TopicNames
+			if topics == nil {
+				topics = []string{"messages"}
+			}
+			for _, topic := range topics {
+				metadataResponse.Topics = append(metadataResponse.Topics, metadata.ResponseTopic{
+					Name: topic,
+					Partitions: []metadata.ResponsePartition{
+						{PartitionIndex: 0, LeaderID: 1, ReplicaNodes: []int32{1}, IsrNodes: []int32{1}},
+					},
+				})
+			}
+			response = metadataResponse
+		case *produce.Request:
+			produceResponse := &produce.Response{}
+			for _, topic := range request.Topics {
+				responseTopic := produce.ResponseTopic{Topic: topic.Topic}
+				for _, partition := range topic.Partitions {
+					if b.errorCode == 0 {
+						b.read(t, topic.Topic, partition.RecordSet.Records)
+					}
+					responseTopic.Partitions = append(responseTopic.Partitions, produce.ResponsePartition{
+						Partition: partition.Partition,
+						ErrorCode: b.errorCode,
+					})
+				}
+				produceResponse.Topics = append(produceResponse.Topics, responseTopic)
+			}
+			response = produceResponse
+		default:
+			t.Logf("unexpected Kafka request %T", request)
+			return
+		}
+		if err := protocol.WriteResponse(conn, version, correlationID, response); err != nil {
+			t.Logf("writing Kafka response: %v", err)
+			return
+		}
+	}
+}
+
+func (b *fakeKafkaBroker) read(t *testing.T, topic string, records protocol.RecordReader) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for {
+		record, err := records.ReadRecord()
+		if err != nil {
+			if err != io.EOF {
+				t.Errorf("reading Kafka records: %v", err)
+			}
+			return
+		}
+		key, _ := protocol.ReadAll(record.Key)
+		value, _ := protocol.ReadAll(record.Value)
+		headers := map[string]string{}
+		for _, header := range record.Headers {
+			headers[header.Key] = string(header.Value)
+		}
+		b.records = append(b.records, kafkaRecord{topic: topic, key: string(key), value: string(value), headers: headers})
+	}
+}
+
+func TestKafkaSinkDeliver(t *testing.T) {
+	broker := newFakeKafkaBroker(t, 0)
+	s, err := NewKafkaSink("kafka", map[string]string{
+		KafkaBrokers:      broker.listener.Addr().String(),
+		KafkaTopic:        "messages",
+		KafkaPartitionKey: KafkaPartitionKeyOrderingKey,
+		KafkaFormat:       FormatCloudEventsBinary,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	message := testMessage()
+	message.Spec.OrderingKey = "db"
+	delivery := NewDelivery(message, s.Name(), "")
+	if err := s.Deliver(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.lock.Lock()
+	defer broker.lock.Unlock()
+	if len(broker.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(broker.records))
+	}
+	record := broker.records[0]
+	if record.topic != "messages" || record.key != "team-a/db" {
+		t.Errorf("got record keyed %q on topic %q, want it keyed by the ordering key on messages", record.key, record.topic)
+	}
+	for header, want := range map[string]string{
+		"content-type":   "application/json",
+		DeliveryHeader:   delivery.ID,
+		"ce_type":        EventBroadcasted,
+		"ce_specversion": CloudEventsSpecVersion,
+	} {
+		if got := record.headers[header]; got != want {
+			t.Errorf("got header %s %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestKafkaSinkErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		errorCode     int16
+		wantPermanent bool
+	}{
+		// NOT_ENOUGH_REPLICAS goes away once the replicas catch up.
+		{name: "not enough replicas", errorCode: 19},
+		// MESSAGE_TOO_LARGE doesn't.
+		{name: "message too large", errorCode: 10, wantPermanent: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := newFakeKafkaBroker(t, test.errorCode)
+			s, err := NewKafkaSink("kafka", map[string]string{
+				KafkaBrokers: broker.listener.Addr().String(),
+				KafkaTopic:   "messages",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+
+			err = s.Deliver(context.Background(), NewDelivery(testMessage(), s.Name(), ""))
+			if err == nil {
+				t.Fatal("delivery the broker failed succeeded")
+			}
+			if IsPermanent(err) != test.wantPermanent {
+				t.Errorf("got permanent %v for %v, want %v", IsPermanent(err), err, test.wantPermanent)
+			}
+		})
+	}
+}
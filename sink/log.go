@@ -0,0 +1,42 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+)
+
+// LogSink broadcasts Messages by printing them to stdout.
+type LogSink struct {
+	name string
+}
+
+func NewLogSink(name string) *LogSink {
+	return &LogSink{name: name}
+}
+
+func (s *LogSink) Name() string {
+	return s.name
+}
+
+func (s *LogSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	message := delivery.Message
+	fmt.Printf("[BROADCAST] %s/%s (urgent: %v, delivery: %s): %s\n",
+		message.ObjectMeta.Namespace, message.ObjectMeta.Name, message.Spec.Urgent, delivery.ID, message.Text())
+	return nil
+}
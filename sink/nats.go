@@ -0,0 +1,256 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Settings of the NATS sink.
+const (
+	// NATSURL is the URL of the NATS server, or a comma separated list of
+	// the URLs of a cluster.
+	NATSURL = "url"
+	// NATSSubject is a text/template of the subject deliveries are
+	// published on. It is executed with the Namespace, Name, Urgent,
+	// Labels, OrderingKey and Event of the delivery. Defaults to
+	// "messages.{{.Namespace}}.{{.Name}}".
+	NATSSubject = "subject"
+	// NATSAcknowledgement is how a delivery is acknowledged: "jetstream",
+	// the default, publishes it to a JetStream stream and waits for the
+	// stream to store it, and "reply" sends it as a request and waits for
+	// a subscriber to reply.
+	NATSAcknowledgement = "acknowledgement"
+	// NATSCABundle is the PEM encoded CA certificates the server
+	// certificate is verified with, instead of the system's. It enables
+	// TLS.
+	NATSCABundle = "caBundle"
+	// NATSTimeout is the timeout of a delivery. Defaults to 10 seconds.
+	NATSTimeout = "timeout"
+	// NATSFormat is how deliveries are encoded, one of the Format
+	// constants. Defaults to FormatEnvelope. The CloudEvents attributes of
+	// binary mode are sent as Ce- headers.
+	NATSFormat = "format"
+)
+
+// Values of NATSAcknowledgement.
+const (
+	NATSAcknowledgementJetStream = "jetstream"
+	NATSAcknowledgementReply     = "reply"
+)
+
+const defaultNATSSubject = "messages.{{.Namespace}}.{{.Name}}"
+
+// natsSubjectData is what the subject template is executed with.
+type natsSubjectData struct {
+	Namespace   string
+	Name        string
+	Urgent      bool
+	Labels      map[string]string
+	OrderingKey string
+	Event       string
+}
+
+// NATSSink broadcasts Messages by publishing them on NATS.
+type NATSSink struct {
+	name            string
+	url             string
+	subject         *template.Template
+	acknowledgement string
+	format          string
+	timeout         time.Duration
+	tls             *tls.Config
+
+	lock sync.Mutex
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func NewNATSSink(name string, settings map[string]string) (*NATSSink, error) {
+	s := &NATSSink{
+		name:            name,
+		url:             settings[NATSURL],
+		acknowledgement: NATSAcknowledgementJetStream,
+		format:          FormatEnvelope,
+		timeout:         10 * time.Second,
+	}
+	if s.url == "" {
+		return nil, fmt.Errorf("setting %s is required", NATSURL)
+	}
+
+	subject := defaultNATSSubject
+	if value, ok := settings[NATSSubject]; ok {
+		subject = value
+	}
+	var err error
+	if s.subject, err = template.New(NATSSubject).Option("missingkey=error").Parse(subject); err != nil {
+		return nil, fmt.Errorf("setting %s: %v", NATSSubject, err)
+	}
+
+	if value, ok := settings[NATSAcknowledgement]; ok {
+		if value != NATSAcknowledgementJetStream && value != NATSAcknowledgementReply {
+			return nil, fmt.Errorf("setting %s must be %s or %s, got %q",
+				NATSAcknowledgement, NATSAcknowledgementJetStream, NATSAcknowledgementReply, value)
+		}
+		s.acknowledgement = value
+	}
+	if format, ok := settings[NATSFormat]; ok {
+		if err := validateFormat(format); err != nil {
+			return nil, fmt.Errorf("setting %s: %v", NATSFormat, err)
+		}
+		s.format = format
+	}
+	if value, ok := settings[NATSTimeout]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("setting %s must be a positive duration, got %q", NATSTimeout, value)
+		}
+		s.timeout = timeout
+	}
+	if bundle, ok := settings[NATSCABundle]; ok {
+		pool, err := certPool(NATSCABundle, bundle)
+		if err != nil {
+			return nil, err
+		}
+		s.tls = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return s, nil
+}
+
+func (s *NATSSink) Name() string {
+	return s.name
+}
+
+// DeliversEvents tells whether deliveries are encoded as CloudEvents, whose
+// consumers want every status change of a Message.
+func (s *NATSSink) DeliversEvents() bool {
+	return s.format != FormatEnvelope
+}
+
+// Deliver publishes delivery and returns once it is acknowledged as
+// NATSAcknowledgement asks.
+func (s *NATSSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	subject, err := s.renderSubject(delivery)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	encoded, err := encode(s.format, delivery)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	msg := nats.NewMsg(subject)
+	msg.Data = encoded.body
+	msg.Header.Set("Content-Type", encoded.contentType)
+	msg.Header.Set(DeliveryHeader, delivery.ID)
+	for name, value := range encoded.attributes {
+		msg.Header.Set("Ce-"+name, value)
+	}
+
+	conn, js, err := s.connect()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if s.acknowledgement == NATSAcknowledgementReply {
+		if _, err := conn.RequestMsgWithContext(ctx, msg); err != nil {
+			return fmt.Errorf("publishing on %s: %v", subject, err)
+		}
+		return nil
+	}
+	// The delivery ID lets the stream drop retries of deliveries it
+	// already stored.
+	if _, err := js.PublishMsg(ctx, msg, jetstream.WithMsgID(delivery.ID)); err != nil {
+		return fmt.Errorf("publishing on %s: %v", subject, err)
+	}
+	return nil
+}
+
+// renderSubject returns the subject of delivery.
+func (s *NATSSink) renderSubject(delivery *Delivery) (string, error) {
+	message := delivery.Message
+	var b bytes.Buffer
+	if err := s.subject.Execute(&b, &natsSubjectData{
+		Namespace:   message.ObjectMeta.Namespace,
+		Name:        message.ObjectMeta.Name,
+		Urgent:      message.Spec.Urgent,
+		Labels:      message.ObjectMeta.Labels,
+		OrderingKey: message.Spec.OrderingKey,
+		Event:       delivery.Event,
+	}); err != nil {
+		return "", fmt.Errorf("rendering subject: %v", err)
+	}
+	subject := b.String()
+	for _, token := range strings.Split(subject, ".") {
+		if token == "" || token == "*" || token == ">" || strings.ContainsAny(token, " \t\r\n") {
+			return "", fmt.Errorf("%q is not a valid subject to publish on", subject)
+		}
+	}
+	return subject, nil
+}
+
+// connect returns the connection to the server, connecting again if it is
+// closed. The client reconnects by itself after the connection is lost, so
+// it is only closed for good when reconnecting fails.
+func (s *NATSSink) connect() (*nats.Conn, jetstream.JetStream, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn != nil && !s.conn.IsClosed() {
+		return s.conn, s.js, nil
+	}
+	options := []nats.Option{
+		nats.Name(DefaultIdentifier),
+		nats.Timeout(s.timeout),
+	}
+	if s.tls != nil {
+		options = append(options, nats.Secure(s.tls))
+	}
+	conn, err := nats.Connect(s.url, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to %s: %v", s.url, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	s.conn, s.js = conn, js
+	return conn, js, nil
+}
+
+func (s *NATSSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// natsMessage is a message published to fakeNATSServer.
+type natsMessage struct {
+	subject string
+	header  textproto.MIMEHeader
+	data    string
+}
+
+// fakeNATSServer speaks enough of the NATS client protocol to take
+// publications and answer requests, standing in for JetStream or a
+// replying subscriber. Every request is answered with reply.
+type fakeNATSServer struct {
+	listener net.Listener
+	reply    string
+
+	lock     sync.Mutex
+	messages []natsMessage
+}
+
+func newFakeNATSServer(t *testing.T, reply string) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeNATSServer{listener: listener, reply: reply}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handle(t, conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeNATSServer) handle(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	var writeLock sync.Mutex
+	write := func(format string, args ...interface{}) {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		fmt.Fprintf(conn, format, args...)
+	}
+	write("INFO {\"server_id\":\"fake\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	// subscriptions maps the subjects subscribed to, without a trailing
+	// wildcard, to their sid.
+	subscriptions := map[string]string{}
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "CONNECT", "UNSUB":
+		case "PING":
+			write("PONG\r\n")
+		case "SUB":
+			subscriptions[strings.TrimSuffix(fields[1], "*")] = fields[len(fields)-1]
+		case "PUB", "HPUB":
+			// The sizes are last, and the header size comes before the
+			// total size for HPUB.
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[len(fields)-2])
+				fields = fields[:len(fields)-1]
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			message := natsMessage{subject: fields[1], data: string(payload[headerSize:size])}
+			if headerSize > 0 {
+				header := bufio.NewReader(strings.NewReader(string(payload[:headerSize])))
+				header.ReadString('\n')
+				message.header, _ = textproto.NewReader(header).ReadMIMEHeader()
+			}
+			s.lock.Lock()
+			s.messages = append(s.messages, message)
+			s.lock.Unlock()
+
+			if len(fields) == 4 {
+				inbox := fields[2]
+				for prefix, sid := range subscriptions {
+					if strings.HasPrefix(inbox, prefix) {
+						write("MSG %s %s %d\r\n%s\r\n", inbox, sid, len(s.reply), s.reply)
+					}
+				}
+			}
+		default:
+			t.Logf("unexpected NATS operation %q", line)
+			return
+		}
+	}
+}
+
+func TestNATSSinkDeliver(t *testing.T) {
+	tests := []struct {
+		name            string
+		acknowledgement string
+		reply           string
+		wantErr         bool
+	}{
+		{name: "stored by JetStream", acknowledgement: NATSAcknowledgementJetStream, reply: `{"stream":"MESSAGES","seq":1}`},
+		{name: "rejected by JetStream", acknowledgement: NATSAcknowledgementJetStream,
+			reply: `{"error":{"code":503,"err_code":10077,"description":"maximum messages exceeded"}}`, wantErr: true},
+		{name: "replied to", acknowledgement: NATSAcknowledgementReply, reply: "ok"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFakeNATSServer(t, test.reply)
+			s, err := NewNATSSink("nats", map[string]string{
+				NATSURL:             server.url(),
+				NATSAcknowledgement: test.acknowledgement,
+				NATSSubject:         "alerts.{{.Namespace}}.{{.Event}}",
+				NATSFormat:          FormatCloudEventsBinary,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+
+			delivery := NewDelivery(testMessage(), s.Name(), "")
+			err = s.Deliver(context.Background(), delivery)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want one: %v", err, test.wantErr)
+			}
+
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.messages) != 1 {
+				t.Fatalf("got %d messages, want 1", len(server.messages))
+			}
+			message := server.messages[0]
+			if want := "alerts.team-a." + EventBroadcasted; message.subject != want {
+				t.Errorf("got subject %q, want %q", message.subject, want)
+			}
+			if got := message.header.Get(DeliveryHeader); got != delivery.ID {
+				t.Errorf("got delivery ID %q, want %q", got, delivery.ID)
+			}
+			if got := message.header.Get("Ce-Type"); got != EventBroadcasted {
+				t.Errorf("got CloudEvents type %q, want %q", got, EventBroadcasted)
+			}
+			if got := message.header.Get("Nats-Msg-Id"); test.acknowledgement == NATSAcknowledgementJetStream && got != delivery.ID {
+				t.Errorf("got JetStream message ID %q, want the delivery ID %q", got, delivery.ID)
+			}
+			if !strings.Contains(message.data, "disk /var is 95% full") {
+				t.Errorf("got data %q", message.data)
+			}
+		})
+	}
+}
+
+func TestNATSSinkSubject(t *testing.T) {
+	s, err := NewNATSSink("nats", map[string]string{
+		NATSURL:     "nats://127.0.0.1:4222",
+		NATSSubject: "alerts.{{.Labels.team}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The subject of a Message without the label can't be rendered.
+	err = s.Deliver(context.Background(), NewDelivery(testMessage(), s.Name(), ""))
+	if !IsPermanent(err) {
+		t.Errorf("got %v, want a permanent error", err)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// secretRefresh is how long the data of a Secret is cached, so that rotated
+// credentials are picked up without reading the Secret for every delivery.
+const secretRefresh = time.Minute
+
+// secretRef is a Secret a sink reads its credentials from.
+type secretRef struct {
+	get       SecretGetter
+	namespace string
+	name      string
+
+	lock      sync.Mutex
+	data      map[string][]byte
+	fetchedAt time.Time
+}
+
+// newSecretRef returns the Secret called name in namespace, or in the
+// namespace of config if namespace is empty. namespaceSetting is the setting
+// namespace comes from, to name in errors.
+//
+// A sink with a namespace in its config can only read Secrets from that
+// namespace, or anyone able to create a Subscription could have the
+// credentials of any namespace sent to a server of their choosing.
+func newSecretRef(config Config, name, namespace, namespaceSetting string) (*secretRef, error) {
+	switch {
+	case config.Namespace == "" && namespace == "":
+		return nil, fmt.Errorf("setting %s is required", namespaceSetting)
+	case config.Namespace == "":
+	case namespace == "":
+		namespace = config.Namespace
+	case namespace != config.Namespace:
+		return nil, fmt.Errorf("setting %s must be empty or %q, Secrets can't be read from other namespaces",
+			namespaceSetting, config.Namespace)
+	}
+	if config.Secrets == nil {
+		return nil, fmt.Errorf("Secret %s/%s can't be read without a Kubernetes client", namespace, name)
+	}
+	return &secretRef{get: config.Secrets, namespace: namespace, name: name}, nil
+}
+
+// value returns the value of key in the Secret, reading the Secret again
+// once the cached data is older than secretRefresh.
+func (r *secretRef) value(ctx context.Context, key string) ([]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.data == nil || time.Since(r.fetchedAt) >= secretRefresh {
+		secret, err := r.get(ctx, r.namespace, r.name)
+		if err != nil {
+			return nil, fmt.Errorf("reading Secret %s/%s: %v", r.namespace, r.name, err)
+		}
+		r.data, r.fetchedAt = secret.Data, time.Now()
+	}
+	value, ok := r.data[key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("Secret %s/%s has no key %q", r.namespace, r.name, key)
+	}
+	return value, nil
+}
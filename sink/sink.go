@@ -0,0 +1,203 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// Sink is a destination Messages are broadcast to. Every delivery to a sink
+// is recorded in the Message status under the sink's name, so names must be
+// unique among the sinks of a controller.
+//
+// Sinks holding connections implement io.Closer as well, and are closed
+// once they are replaced.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, delivery *Delivery) error
+}
+
+// Delivery is one attempt to deliver a Message to a sink.
+type Delivery struct {
+	// ID is the same for every attempt of the same delivery, so that
+	// receivers can drop the ones they already got. Sinks should pass it
+	// on if their protocol allows.
+	ID string
+	// Stage is the stage passed to NewDelivery.
+	Stage string
+	// Event is the state change of the Message the delivery announces.
+	Event   string
+	Message *messagev1.Message
+}
+
+// DeliveryHeader is the header holding the delivery ID, for the sinks whose
+// protocol has headers.
+const DeliveryHeader = "X-Message-Delivery"
+
+// Events deliveries announce.
+const (
+	// EventCreated is a new Message getting its first state.
+	EventCreated = "message.created"
+	// EventScheduled is a Message waiting to be delivered later.
+	EventScheduled = "message.scheduled"
+	// EventBroadcasted is the original broadcast of a Message.
+	EventBroadcasted = "message.broadcasted"
+	// EventEscalated is any later broadcast of a Message, because it went
+	// unacknowledged or undelivered.
+	EventEscalated = "message.escalated"
+	// EventAcknowledged is a Message being acknowledged.
+	EventAcknowledged = "message.acknowledged"
+	// EventSuppressed is a Message suppressed as a duplicate.
+	EventSuppressed = "message.suppressed"
+	// EventFailed is a Message given up on and dead-lettered.
+	EventFailed = "message.failed"
+)
+
+// NewDelivery returns the delivery of message to the sink called sinkName.
+// stage tells apart deliveries of the same message to the same sink, such
+// as an escalation from the original broadcast, and is empty for the
+// original broadcast.
+//
+// The ID is derived from the idempotency key of message if it has one, so
+// that it also stays the same across Messages created by retries of the
+// same request, and from its UID otherwise.
+func NewDelivery(message *messagev1.Message, sinkName, stage string) *Delivery {
+	base := "uid:" + string(message.ObjectMeta.UID)
+	if message.Spec.IdempotencyKey != "" {
+		base = "key:" + message.ObjectMeta.Namespace + "/" + message.Spec.IdempotencyKey
+	}
+	sum := sha256.Sum256([]byte(base + "\x00" + sinkName + "\x00" + stage))
+	event := EventBroadcasted
+	if stage != "" {
+		event = EventEscalated
+	}
+	return &Delivery{
+		ID:      hex.EncodeToString(sum[:16]),
+		Stage:   stage,
+		Event:   event,
+		Message: message,
+	}
+}
+
+// NewEventDelivery returns the delivery of event, a change of the status of
+// message announced by the Events of EventSinks, to the sink called
+// sinkName.
+func NewEventDelivery(message *messagev1.Message, sinkName, event string) *Delivery {
+	delivery := NewDelivery(message, sinkName, event)
+	delivery.Event = event
+	return delivery
+}
+
+// SecretGetter reads a Secret.
+type SecretGetter func(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+
+// Config is what sinks are built with besides their settings.
+type Config struct {
+	// Namespace is the namespace of the resource the sink is configured
+	// by, if it is namespaced. The Secrets named by the settings must be
+	// in it. Otherwise the settings must name the namespace of any Secret.
+	Namespace string
+	// Secrets reads the Secrets named by the settings.
+	Secrets SecretGetter
+}
+
+// New returns a sink of type sinkType called name, configured by settings.
+func New(name string, sinkType messagev1.SinkType, settings map[string]string, config Config) (Sink, error) {
+	switch sinkType {
+	case messagev1.SinkTypeLog:
+		return NewLogSink(name), nil
+	case messagev1.SinkTypeWebhook:
+		return NewWebhookSink(name, settings, config)
+	case messagev1.SinkTypeSMTP:
+		return NewSMTPSink(name, settings, config)
+	case messagev1.SinkTypeSyslog:
+		return NewSyslogSink(name, settings)
+	case messagev1.SinkTypeJournald:
+		return NewJournaldSink(name, settings)
+	case messagev1.SinkTypeKafka:
+		return NewKafkaSink(name, settings)
+	case messagev1.SinkTypeNATS:
+		return NewNATSSink(name, settings)
+	}
+	return nil, fmt.Errorf("unsupported sink type %q", sinkType)
+}
+
+// ForChannel returns the sink delivering the Messages of channel. The sink
+// is named after the channel, so Message deliveries are recorded per channel.
+// Channels are cluster-scoped, so their settings must name the namespace of
+// any Secret they use.
+func ForChannel(channel *messagev1.BroadcastChannel, secrets SecretGetter) (Sink, error) {
+	return New(channel.ObjectMeta.Name, channel.Spec.Sink, channel.Spec.Settings, Config{Secrets: secrets})
+}
+
+// SubscriptionName returns the name of the sink of subscription,
+// "subscription/<namespace>/<name>", which its deliveries are recorded as.
+// Sinks of same-named Subscriptions in different namespaces are told apart,
+// so that they don't share circuit breakers or rate limits.
+func SubscriptionName(subscription *messagev1.Subscription) string {
+	return "subscription/" + subscription.ObjectMeta.Namespace + "/" + subscription.ObjectMeta.Name
+}
+
+// ForSubscription returns the sink delivering Messages to the recipient of
+// subscription, named SubscriptionName. The sink can only read Secrets in
+// the namespace of subscription.
+func ForSubscription(subscription *messagev1.Subscription, secrets SecretGetter) (Sink, error) {
+	recipient := subscription.Spec.Recipient
+	return New(SubscriptionName(subscription), recipient.Sink, recipient.Settings, Config{
+		Namespace: subscription.ObjectMeta.Namespace,
+		Secrets:   secrets,
+	})
+}
+
+// certPool returns the PEM encoded certificates of bundle, the value of
+// setting.
+func certPool(setting, bundle string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(bundle)) {
+		return nil, fmt.Errorf("setting %s holds no PEM encoded certificate", setting)
+	}
+	return pool, nil
+}
+
+// PermanentError is a delivery error retrying won't fix, such as the
+// receiver rejecting the delivery as invalid.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent tells whether err is a PermanentError.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	messagev1 "github.com/yasker/example-crd/apis/message/v1"
+)
+
+// testMessage returns a Message to deliver in tests.
+func testMessage() *messagev1.Message {
+	return &messagev1.Message{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "team-a",
+			Name:       "disk-full",
+			UID:        "6a1c0b5e-3f1e-4a7d-9a51-1f0e8b1c2d3e",
+			Generation: 1,
+			Labels:     map[string]string{"app": "db"},
+		},
+		Spec: messagev1.MessageSpec{
+			Context: "disk /var is 95% full",
+			Urgent:  true,
+		},
+	}
+}
+
+// testSecrets returns a SecretGetter reading secrets.
+func testSecrets(secrets ...*corev1.Secret) SecretGetter {
+	return func(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+		for _, secret := range secrets {
+			if secret.Namespace == namespace && secret.Name == name {
+				return secret, nil
+			}
+		}
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+}
+
+func TestNewSecretRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    Config
+		namespace string
+		want      string
+		wantErr   bool
+	}{
+		{name: "subscription default", config: Config{Namespace: "team-a"}, want: "team-a"},
+		{name: "subscription own namespace", config: Config{Namespace: "team-a"}, namespace: "team-a", want: "team-a"},
+		{name: "subscription other namespace", config: Config{Namespace: "team-a"}, namespace: "kube-system", wantErr: true},
+		{name: "channel", namespace: "kube-system", want: "kube-system"},
+		{name: "channel without namespace", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Secrets = testSecrets()
+			ref, err := newSecretRef(test.config, "credentials", test.namespace, "secretNamespace")
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got Secret %s/%s, want an error", ref.namespace, ref.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ref.namespace != test.want {
+				t.Errorf("got namespace %q, want %q", ref.namespace, test.want)
+			}
+		})
+	}
+}
+
+func TestForSubscriptionSecretNamespace(t *testing.T) {
+	settings := map[string]string{
+		SMTPHost:                "smtp.example.com",
+		SMTPFrom:                "alerts@example.com",
+		SMTPTo:                  "oncall@example.com",
+		SMTPAuthSecretName:      "smtp-credentials",
+		SMTPAuthSecretNamespace: "kube-system",
+	}
+	subscription := &messagev1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "oncall"},
+		Spec: messagev1.SubscriptionSpec{
+			Recipient: messagev1.Recipient{Sink: messagev1.SinkTypeSMTP, Settings: settings},
+		},
+	}
+	if _, err := ForSubscription(subscription, testSecrets()); err == nil {
+		t.Error("Subscription in team-a can use a Secret in kube-system")
+	}
+
+	channel := &messagev1.BroadcastChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "oncall"},
+		Spec:       messagev1.BroadcastChannelSpec{Sink: messagev1.SinkTypeSMTP, Settings: settings},
+	}
+	if _, err := ForChannel(channel, testSecrets()); err != nil {
+		t.Errorf("channel can't use a Secret in kube-system: %v", err)
+	}
+}
+
+func TestForSubscriptionName(t *testing.T) {
+	var names []string
+	for _, namespace := range []string{"team-a", "team-b"} {
+		subscription := &messagev1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "oncall"},
+			Spec: messagev1.SubscriptionSpec{
+				Recipient: messagev1.Recipient{Sink: messagev1.SinkTypeLog},
+			},
+		}
+		s, err := ForSubscription(subscription, testSecrets())
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, s.Name())
+	}
+	if names[0] != "subscription/team-a/oncall" || names[1] != "subscription/team-b/oncall" {
+		t.Errorf("got sinks %q, want them named after the namespace and name of their Subscription", names)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	acknowledgement := func(state messagev1.AcknowledgementState) *messagev1.AcknowledgementStatus {
+		return &messagev1.AcknowledgementStatus{State: state}
+	}
+	tests := []struct {
+		name string
+		old  messagev1.MessageStatus
+		new  messagev1.MessageStatus
+		want []string
+	}{
+		{name: "created", new: messagev1.MessageStatus{State: messagev1.MessageStateCreated}, want: []string{EventCreated}},
+		{name: "created and broadcasted", new: messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted}, want: []string{EventCreated}},
+		{name: "created and scheduled", new: messagev1.MessageStatus{State: messagev1.MessageStateScheduled}, want: []string{EventCreated, EventScheduled}},
+		{name: "suppressed", old: messagev1.MessageStatus{State: messagev1.MessageStateCreated}, new: messagev1.MessageStatus{State: messagev1.MessageStateSuppressed}, want: []string{EventSuppressed}},
+		{name: "failed", old: messagev1.MessageStatus{State: messagev1.MessageStateCreated}, new: messagev1.MessageStatus{State: messagev1.MessageStateFailed}, want: []string{EventFailed}},
+		{name: "still scheduled", old: messagev1.MessageStatus{State: messagev1.MessageStateScheduled}, new: messagev1.MessageStatus{State: messagev1.MessageStateScheduled}},
+		{
+			name: "acknowledged",
+			old:  messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted, Acknowledgement: acknowledgement(messagev1.AcknowledgementStatePending)},
+			new:  messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted, Acknowledgement: acknowledgement(messagev1.AcknowledgementStateAcknowledged)},
+			want: []string{EventAcknowledged},
+		},
+		{
+			name: "still acknowledged",
+			old:  messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted, Acknowledgement: acknowledgement(messagev1.AcknowledgementStateAcknowledged)},
+			new:  messagev1.MessageStatus{State: messagev1.MessageStateBroadcasted, Acknowledgement: acknowledgement(messagev1.AcknowledgementStateAcknowledged)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Events(&test.old, &test.new)
+			if len(got) != len(test.want) {
+				t.Fatalf("got events %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("got events %q, want %q", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewEventDelivery(t *testing.T) {
+	message := testMessage()
+	event := NewCloudEvent(NewEventDelivery(message, "events", EventAcknowledged))
+	if event.Type != EventAcknowledged {
+		t.Errorf("got type %q, want %q", event.Type, EventAcknowledged)
+	}
+	broadcast := NewCloudEvent(NewDelivery(message, "events", ""))
+	if event.ID == broadcast.ID {
+		t.Errorf("acknowledgement has the ID %q of the broadcast", event.ID)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Settings of the SMTP sink.
+const (
+	// SMTPHost is the host name of the SMTP server.
+	SMTPHost = "host"
+	// SMTPPort is the port of the SMTP server. Defaults to 587 for
+	// STARTTLS, 465 for TLS and 25 otherwise.
+	SMTPPort = "port"
+	// SMTPSecurity is how the connection is secured: "starttls", the
+	// default, "tls" or "none".
+	SMTPSecurity = "security"
+	// SMTPCABundle is the PEM encoded CA certificates the server
+	// certificate is verified with, instead of the system's.
+	SMTPCABundle = "caBundle"
+	// SMTPTimeout is the timeout of a delivery, e.g. "30s". Defaults to
+	// 30 seconds.
+	SMTPTimeout = "timeout"
+	// SMTPAuthSecretName names the Secret holding the "username" and
+	// "password" to authenticate with, using PLAIN, which needs a secured
+	// connection. Deliveries are not authenticated without one.
+	SMTPAuthSecretName = "authSecretName"
+	// SMTPAuthSecretNamespace is the namespace of the auth Secret.
+	// Required for channels. Subscriptions can only use Secrets in their
+	// own namespace, which is the default.
+	SMTPAuthSecretNamespace = "authSecretNamespace"
+	// SMTPFrom is the address emails are sent from.
+	SMTPFrom = "from"
+	// SMTPTo is a comma separated list of the addresses emails are sent
+	// to.
+	SMTPTo = "to"
+	// SMTPFromLabel names a label of the Messages holding the local part
+	// of the address to send from, instead of SMTPFrom. Label values
+	// can't hold an "@", so SMTPLabelDomain is appended.
+	SMTPFromLabel = "fromLabel"
+	// SMTPToLabel names a label of the Messages holding the local part of
+	// the address to send to, instead of SMTPTo.
+	SMTPToLabel = "toLabel"
+	// SMTPLabelDomain is the domain of the addresses taken from labels.
+	SMTPLabelDomain = "labelDomain"
+	// SMTPSubject is a text/template of the subject. It is executed with
+	// the Namespace, Name, Urgent and Labels of the Message.
+	SMTPSubject = "subject"
+)
+
+// Values of SMTPSecurity.
+const (
+	SMTPSecurityStartTLS = "starttls"
+	SMTPSecurityTLS      = "tls"
+	SMTPSecurityNone     = "none"
+)
+
+const defaultSMTPSubject = `{{if .Urgent}}[URGENT] {{end}}Message {{.Namespace}}/{{.Name}}`
+
+// smtpSubjectData is what the subject template is executed with.
+type smtpSubjectData struct {
+	Namespace string
+	Name      string
+	Urgent    bool
+	Labels    map[string]string
+}
+
+// SMTPSink broadcasts Messages by email.
+type SMTPSink struct {
+	name     string
+	host     string
+	address  string
+	security string
+	tls      *tls.Config
+	timeout  time.Duration
+	subject  *template.Template
+
+	from        string
+	to          []string
+	fromLabel   string
+	toLabel     string
+	labelDomain string
+
+	authSecret *secretRef
+}
+
+func NewSMTPSink(name string, settings map[string]string, config Config) (*SMTPSink, error) {
+	s := &SMTPSink{
+		name:        name,
+		host:        settings[SMTPHost],
+		security:    SMTPSecurityStartTLS,
+		timeout:     30 * time.Second,
+		from:        settings[SMTPFrom],
+		fromLabel:   settings[SMTPFromLabel],
+		toLabel:     settings[SMTPToLabel],
+		labelDomain: settings[SMTPLabelDomain],
+	}
+	if s.host == "" {
+		return nil, fmt.Errorf("setting %s is required", SMTPHost)
+	}
+
+	port := 587
+	if security, ok := settings[SMTPSecurity]; ok {
+		switch security {
+		case SMTPSecurityStartTLS:
+		case SMTPSecurityTLS:
+			port = 465
+		case SMTPSecurityNone:
+			port = 25
+		default:
+			return nil, fmt.Errorf("setting %s must be %s, %s or %s, got %q",
+				SMTPSecurity, SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone, security)
+		}
+		s.security = security
+	}
+	if value, ok := settings[SMTPPort]; ok {
+		var err error
+		if port, err = strconv.Atoi(value); err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("setting %s must be a port number, got %q", SMTPPort, value)
+		}
+	}
+	s.address = net.JoinHostPort(s.host, strconv.Itoa(port))
+
+	s.tls = &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+	if bundle, ok := settings[SMTPCABundle]; ok {
+		pool, err := certPool(SMTPCABundle, bundle)
+		if err != nil {
+			return nil, err
+		}
+		s.tls.RootCAs = pool
+	}
+
+	if value, ok := settings[SMTPTimeout]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("setting %s must be a positive duration, got %q", SMTPTimeout, value)
+		}
+		s.timeout = timeout
+	}
+
+	subject := defaultSMTPSubject
+	if value, ok := settings[SMTPSubject]; ok {
+		subject = value
+	}
+	var err error
+	if s.subject, err = template.New(SMTPSubject).Option("missingkey=error").Parse(subject); err != nil {
+		return nil, fmt.Errorf("setting %s: %v", SMTPSubject, err)
+	}
+
+	if s.from != "" {
+		if _, err := mail.ParseAddress(s.from); err != nil {
+			return nil, fmt.Errorf("setting %s: %v", SMTPFrom, err)
+		}
+	} else if s.fromLabel == "" {
+		return nil, fmt.Errorf("setting %s or %s is required", SMTPFrom, SMTPFromLabel)
+	}
+	if value := settings[SMTPTo]; value != "" {
+		for _, to := range strings.Split(value, ",") {
+			address, err := mail.ParseAddress(strings.TrimSpace(to))
+			if err != nil {
+				return nil, fmt.Errorf("setting %s: %v", SMTPTo, err)
+			}
+			s.to = append(s.to, address.Address)
+		}
+	} else if s.toLabel == "" {
+		return nil, fmt.Errorf("setting %s or %s is required", SMTPTo, SMTPToLabel)
+	}
+	if (s.fromLabel != "" || s.toLabel != "") && s.labelDomain == "" {
+		return nil, fmt.Errorf("setting %s is required to take addresses from labels", SMTPLabelDomain)
+	}
+
+	if name := settings[SMTPAuthSecretName]; name != "" {
+		// PLAIN would send the password in the clear.
+		if s.security == SMTPSecurityNone {
+			return nil, fmt.Errorf("setting %s needs %s %s or %s, not %s", SMTPAuthSecretName, SMTPSecurity, SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone)
+		}
+		if s.authSecret, err = newSecretRef(config, name, settings[SMTPAuthSecretNamespace], SMTPAuthSecretNamespace); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *SMTPSink) Name() string {
+	return s.name
+}
+
+func (s *SMTPSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	from, to, err := s.addresses(delivery)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	body, err := s.compose(delivery, from, to)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	return smtpError(s.send(ctx, from.Address, to, body))
+}
+
+// addresses returns who delivery is sent from and to, preferring the
+// addresses in the labels of the Message.
+func (s *SMTPSink) addresses(delivery *Delivery) (*mail.Address, []string, error) {
+	labels := delivery.Message.ObjectMeta.Labels
+
+	from := s.from
+	if local := labels[s.fromLabel]; s.fromLabel != "" && local != "" {
+		from = local + "@" + s.labelDomain
+	}
+	if from == "" {
+		return nil, nil, fmt.Errorf("Message has no label %s to send from", s.fromLabel)
+	}
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sending from %q: %v", from, err)
+	}
+
+	to := s.to
+	if local := labels[s.toLabel]; s.toLabel != "" && local != "" {
+		address, err := mail.ParseAddress(local + "@" + s.labelDomain)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sending to label %s: %v", s.toLabel, err)
+		}
+		to = []string{address.Address}
+	}
+	if len(to) == 0 {
+		return nil, nil, fmt.Errorf("Message has no label %s to send to", s.toLabel)
+	}
+	return fromAddress, to, nil
+}
+
+// compose returns the email of delivery.
+func (s *SMTPSink) compose(delivery *Delivery, from *mail.Address, to []string) ([]byte, error) {
+	message := delivery.Message
+	var subject bytes.Buffer
+	if err := s.subject.Execute(&subject, &smtpSubjectData{
+		Namespace: message.ObjectMeta.Namespace,
+		Name:      message.ObjectMeta.Name,
+		Urgent:    message.Spec.Urgent,
+		Labels:    message.ObjectMeta.Labels,
+	}); err != nil {
+		return nil, fmt.Errorf("rendering subject: %v", err)
+	}
+
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+	var email bytes.Buffer
+	header := func(name, value string) {
+		// Labels end up in headers, so they must not be able to add
+		// headers of their own.
+		value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+		fmt.Fprintf(&email, "%s: %s\r\n", name, value)
+	}
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject.String()))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+delivery.ID+"@"+domain+">")
+	if message.Spec.Urgent {
+		header("Importance", "high")
+		header("X-Priority", "1")
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "quoted-printable")
+	email.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&email)
+	if _, err := w.Write([]byte(message.Text())); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return email.Bytes(), nil
+}
+
+// send sends body from from to to.
+func (s *SMTPSink) send(ctx context.Context, from string, to []string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	if s.security == SMTPSecurityTLS {
+		tlsConn := tls.Client(conn, s.tls)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return err
+		}
+		conn = tlsConn
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return &PermanentError{Err: fmt.Errorf("SMTP server %s does not support STARTTLS", s.address)}
+		}
+		if err := client.StartTLS(s.tls); err != nil {
+			return err
+		}
+	}
+	if s.authSecret != nil {
+		username, err := s.authSecret.value(ctx, "username")
+		if err != nil {
+			return err
+		}
+		password, err := s.authSecret.value(ctx, "password")
+		if err != nil {
+			return err
+		}
+		if err := client.Auth(smtp.PlainAuth("", string(username), string(password), s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, address := range to {
+		if err := client.Rcpt(address); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// smtpError marks the permanent SMTP replies of err as permanent.
+func smtpError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeSMTPServer is an SMTP server that accepts any email, except for the
+// recipients it rejects.
+type fakeSMTPServer struct {
+	listener net.Listener
+	// reject maps recipients to the reply their RCPT gets.
+	reject map[string]string
+	// certificate is the certificate of a server using TLS, nil for one
+	// without.
+	certificate *x509.Certificate
+
+	lock  sync.Mutex
+	auth  string
+	from  string
+	to    []string
+	email string
+}
+
+// newFakeSMTPServer starts a fakeSMTPServer, using TLS if security is
+// SMTPSecurityTLS.
+func newFakeSMTPServer(t *testing.T, reject map[string]string, security string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTPServer{listener: listener, reject: reject}
+	if security == SMTPSecurityTLS {
+		// Borrow the certificate of httptest, which is valid for
+		// 127.0.0.1.
+		https := httptest.NewTLSServer(nil)
+		server.certificate = https.Certificate()
+		listener = tls.NewListener(listener, &tls.Config{Certificates: https.TLS.Certificates})
+		server.listener = listener
+		https.Close()
+	}
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command, argument, _ := strings.Cut(line, " ")
+		s.lock.Lock()
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			reply("250-fake")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(argument, "PLAIN "))
+			s.auth = string(credentials)
+			reply("235 authenticated")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(argument, "FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			to := strings.Trim(strings.TrimPrefix(argument, "TO:"), "<>")
+			if rejection, ok := s.reject[to]; ok {
+				reply(rejection)
+				break
+			}
+			s.to = append(s.to, to)
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var email strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					s.lock.Unlock()
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				email.WriteString(line)
+			}
+			s.email = email.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			s.lock.Unlock()
+			return
+		default:
+			reply("502 unknown command")
+		}
+		s.lock.Unlock()
+	}
+}
+
+func (s *fakeSMTPServer) settings() map[string]string {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	settings := map[string]string{
+		SMTPHost:     host,
+		SMTPPort:     port,
+		SMTPSecurity: SMTPSecurityNone,
+		SMTPFrom:     "alerts@example.com",
+		SMTPTo:       "oncall@example.com",
+	}
+	if s.certificate != nil {
+		settings[SMTPSecurity] = SMTPSecurityTLS
+		settings[SMTPCABundle] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.certificate.Raw}))
+	}
+	return settings
+}
+
+func TestSMTPSinkDeliver(t *testing.T) {
+	server := newFakeSMTPServer(t, nil, SMTPSecurityTLS)
+	settings := server.settings()
+	settings[SMTPAuthSecretName] = "smtp-credentials"
+	settings[SMTPToLabel] = "owner"
+	settings[SMTPLabelDomain] = "example.com"
+	secrets := testSecrets(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "smtp-credentials"},
+		Data:       map[string][]byte{"username": []byte("alerts"), "password": []byte("hunter2")},
+	})
+	s, err := NewSMTPSink("oncall", settings, Config{Namespace: "team-a", Secrets: secrets})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := testMessage()
+	message.ObjectMeta.Labels["owner"] = "dba"
+	delivery := NewDelivery(message, s.Name(), "")
+	if err := s.Deliver(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	if server.auth != "\x00alerts\x00hunter2" {
+		t.Errorf("got PLAIN credentials %q", server.auth)
+	}
+	if server.from != "alerts@example.com" {
+		t.Errorf("got sender %q", server.from)
+	}
+	if len(server.to) != 1 || server.to[0] != "dba@example.com" {
+		t.Errorf("got recipients %q, want the address in the label", server.to)
+	}
+	for _, want := range []string{
+		"Subject: [URGENT] Message team-a/disk-full\r\n",
+		"Message-ID: <" + delivery.ID + "@example.com>\r\n",
+		"Importance: high\r\n",
+		"disk /var is 95% full",
+	} {
+		if !strings.Contains(server.email, want) {
+			t.Errorf("email has no %q:\n%s", want, server.email)
+		}
+	}
+}
+
+func TestSMTPSinkRejected(t *testing.T) {
+	tests := []struct {
+		name          string
+		reply         string
+		wantPermanent bool
+	}{
+		{name: "mailbox unavailable", reply: "550 no such user", wantPermanent: true},
+		{name: "try again later", reply: "451 greylisted", wantPermanent: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, map[string]string{"oncall@example.com": test.reply}, SMTPSecurityNone)
+			s, err := NewSMTPSink("oncall", server.settings(), Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Deliver(context.Background(), NewDelivery(testMessage(), s.Name(), ""))
+			if err == nil {
+				t.Fatal("delivery to a rejected recipient succeeded")
+			}
+			if IsPermanent(err) != test.wantPermanent {
+				t.Errorf("got permanent %v for %v, want %v", IsPermanent(err), err, test.wantPermanent)
+			}
+		})
+	}
+}
+
+func TestNewSMTPSinkAuth(t *testing.T) {
+	tests := []struct {
+		security   string
+		authSecret string
+		wantErr    bool
+	}{
+		{security: SMTPSecurityStartTLS, authSecret: "smtp-credentials"},
+		{security: SMTPSecurityTLS, authSecret: "smtp-credentials"},
+		{security: SMTPSecurityNone},
+		{security: SMTPSecurityNone, authSecret: "smtp-credentials", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.security+"/"+test.authSecret, func(t *testing.T) {
+			settings := map[string]string{
+				SMTPHost:           "smtp.example.com",
+				SMTPSecurity:       test.security,
+				SMTPFrom:           "alerts@example.com",
+				SMTPTo:             "oncall@example.com",
+				SMTPAuthSecretName: test.authSecret,
+			}
+			_, err := NewSMTPSink("oncall", settings, Config{Namespace: "team-a", Secrets: testSecrets()})
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want one: %v", err, test.wantErr)
+			}
+		})
+	}
+}
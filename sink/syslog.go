@@ -0,0 +1,235 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Settings of the Syslog sink.
+const (
+	// SyslogNetwork is how the syslog server is reached: "udp", the
+	// default, "tcp", "unixgram" or "unix". Messages sent over a stream
+	// are framed by octet counting, as in RFC 6587.
+	SyslogNetwork = "network"
+	// SyslogAddress is the host:port of the syslog server, or the path of
+	// its socket. Defaults to /dev/log for the unix networks.
+	SyslogAddress = "address"
+	// SyslogFacility is the facility of the messages, by its name, e.g.
+	// "daemon" or "local0". Defaults to "user".
+	SyslogFacility = "facility"
+	// SyslogAppName is the APP-NAME of the messages. Defaults to
+	// DefaultIdentifier.
+	SyslogAppName = "appName"
+	// SyslogTimeout is the timeout of a delivery. Defaults to 5 seconds.
+	SyslogTimeout = "timeout"
+)
+
+// DefaultIdentifier is what the Syslog and Journald sinks identify the
+// messages they write with by default.
+const DefaultIdentifier = "message-broadcaster"
+
+// Syslog severities of the messages written for Messages.
+const (
+	severityCritical = 2
+	severityNotice   = 5
+)
+
+// severity returns the syslog severity of the delivery of message: urgent
+// Messages are critical, others are notices.
+func severity(delivery *Delivery) int {
+	if delivery.Message.Spec.Urgent {
+		return severityCritical
+	}
+	return severityNotice
+}
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSDID is the SD-ID of the structured data of the messages. 32473 is
+// the enterprise number reserved for documentation and examples.
+const syslogSDID = "message@32473"
+
+// SyslogSink broadcasts Messages by writing them to syslog as RFC 5424
+// messages.
+type SyslogSink struct {
+	name     string
+	network  string
+	address  string
+	facility int
+	appName  string
+	hostname string
+	timeout  time.Duration
+
+	lock sync.Mutex
+	conn net.Conn
+}
+
+func NewSyslogSink(name string, settings map[string]string) (*SyslogSink, error) {
+	s := &SyslogSink{
+		name:     name,
+		network:  "udp",
+		address:  settings[SyslogAddress],
+		facility: syslogFacilities["user"],
+		appName:  DefaultIdentifier,
+		timeout:  5 * time.Second,
+	}
+
+	if network, ok := settings[SyslogNetwork]; ok {
+		switch network {
+		case "udp", "tcp", "unixgram", "unix":
+		default:
+			return nil, fmt.Errorf("setting %s must be udp, tcp, unixgram or unix, got %q", SyslogNetwork, network)
+		}
+		s.network = network
+	}
+	if s.address == "" {
+		if !strings.HasPrefix(s.network, "unix") {
+			return nil, fmt.Errorf("setting %s is required", SyslogAddress)
+		}
+		s.address = "/dev/log"
+	}
+	if facility, ok := settings[SyslogFacility]; ok {
+		if s.facility, ok = syslogFacilities[facility]; !ok {
+			return nil, fmt.Errorf("setting %s: unknown facility %q", SyslogFacility, facility)
+		}
+	}
+	if appName, ok := settings[SyslogAppName]; ok {
+		if appName == "" || len(appName) > 48 || !printableASCII(appName) {
+			return nil, fmt.Errorf("setting %s must be 1 to 48 printable ASCII characters, got %q", SyslogAppName, appName)
+		}
+		s.appName = appName
+	}
+	if value, ok := settings[SyslogTimeout]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("setting %s must be a positive duration, got %q", SyslogTimeout, value)
+		}
+		s.timeout = timeout
+	}
+
+	s.hostname = "-"
+	if hostname, err := os.Hostname(); err == nil && hostname != "" && printableASCII(hostname) {
+		s.hostname = hostname
+	}
+	return s, nil
+}
+
+func (s *SyslogSink) Name() string {
+	return s.name
+}
+
+func (s *SyslogSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	message := s.format(delivery)
+	if s.network == "tcp" || s.network == "unix" {
+		message = append([]byte(strconv.Itoa(len(message))+" "), message...)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// A connection kept open may have been closed by the server since,
+	// so a failed write is tried once more on a new connection.
+	for retry := 0; ; retry++ {
+		if s.conn == nil {
+			dialer := &net.Dialer{Timeout: s.timeout}
+			conn, err := dialer.DialContext(ctx, s.network, s.address)
+			if err != nil {
+				return err
+			}
+			s.conn = conn
+		}
+		s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+		_, err := s.conn.Write(message)
+		if err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+		if retry == 1 {
+			return err
+		}
+	}
+}
+
+// Close closes the connection to the syslog server, if any.
+func (s *SyslogSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format returns the RFC 5424 message of delivery.
+func (s *SyslogSink) format(delivery *Delivery) []byte {
+	message := delivery.Message
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %d %s ",
+		s.facility*8+severity(delivery),
+		time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		s.hostname, s.appName, os.Getpid(), delivery.Event)
+
+	fmt.Fprintf(&b, "[%s", syslogSDID)
+	for _, param := range []struct{ name, value string }{
+		{"namespace", message.ObjectMeta.Namespace},
+		{"name", message.ObjectMeta.Name},
+		{"uid", string(message.ObjectMeta.UID)},
+		{"urgent", strconv.FormatBool(message.Spec.Urgent)},
+		{"delivery", delivery.ID},
+	} {
+		fmt.Fprintf(&b, ` %s="%s"`, param.name, escapeSDParam(param.value))
+	}
+	b.WriteString("] ")
+
+	// The BOM marks the text as UTF-8.
+	b.WriteString("\ufeff")
+	b.WriteString(message.Text())
+	return b.Bytes()
+}
+
+// escapeSDParam escapes the characters RFC 5424 requires to be escaped in
+// the values of structured data.
+func escapeSDParam(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+func printableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// syslogListener receives the messages a SyslogSink writes, one per call
+// of next.
+type syslogListener struct {
+	address string
+	next    func() (string, error)
+}
+
+// listenSyslog listens on a local address of network, reading the messages
+// of streams by their octet counting.
+func listenSyslog(t *testing.T, network string) *syslogListener {
+	address := "127.0.0.1:0"
+	if strings.HasPrefix(network, "unix") {
+		address = filepath.Join(t.TempDir(), "log.sock")
+	}
+
+	if network == "udp" || network == "unixgram" {
+		conn, err := net.ListenPacket(network, address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return &syslogListener{
+			address: conn.LocalAddr().String(),
+			next: func() (string, error) {
+				buf := make([]byte, 64*1024)
+				conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+				n, _, err := conn.ReadFrom(buf)
+				return string(buf[:n]), err
+			},
+		}
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	messages := make(chan string, 16)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+			if err != nil {
+				messages <- "bad frame length " + length
+				return
+			}
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			messages <- string(buf)
+		}
+	}()
+	return &syslogListener{
+		address: listener.Addr().String(),
+		next: func() (string, error) {
+			select {
+			case message := <-messages:
+				return message, nil
+			case <-time.After(5 * time.Second):
+				return "", context.DeadlineExceeded
+			}
+		},
+	}
+}
+
+func TestSyslogSinkDeliver(t *testing.T) {
+	for _, network := range []string{"udp", "tcp", "unixgram", "unix"} {
+		t.Run(network, func(t *testing.T) {
+			listener := listenSyslog(t, network)
+			s, err := NewSyslogSink("syslog", map[string]string{
+				SyslogNetwork:  network,
+				SyslogAddress:  listener.address,
+				SyslogFacility: "local0",
+				SyslogAppName:  "broadcaster",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+
+			// Two deliveries, so that the framing of streams is
+			// checked too.
+			message := testMessage()
+			for i, urgent := range []bool{true, false} {
+				message.Spec.Urgent = urgent
+				delivery := NewDelivery(message, s.Name(), strconv.Itoa(i))
+				if err := s.Deliver(context.Background(), delivery); err != nil {
+					t.Fatal(err)
+				}
+				got, err := listener.next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				// local0 is facility 16, urgent Messages are
+				// critical (2) and others notices (5).
+				priority := "<130>1 "
+				if !urgent {
+					priority = "<133>1 "
+				}
+				for _, want := range []string{
+					" broadcaster ",
+					" " + delivery.Event + " ",
+					`[message@32473 namespace="team-a" name="disk-full"`,
+					`delivery="` + delivery.ID + `"]`,
+					"\ufeffdisk /var is 95% full",
+				} {
+					if !strings.HasPrefix(got, priority) || !strings.Contains(got, want) {
+						t.Errorf("message %q doesn't start with %q and hold %q", got, priority, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEscapeSDParam(t *testing.T) {
+	if got, want := escapeSDParam(`a"b]c\d`), `a\"b\]c\\d`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
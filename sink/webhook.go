@@ -0,0 +1,230 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings of the Webhook sink.
+const (
+	// WebhookURL is the http or https URL deliveries are POSTed to.
+	WebhookURL = "url"
+	// WebhookTimeout is the timeout of a delivery, e.g. "5s". Defaults to
+	// 10 seconds.
+	WebhookTimeout = "timeout"
+	// WebhookCABundle is the PEM encoded CA certificates the server
+	// certificate is verified with, instead of the system's.
+	WebhookCABundle = "caBundle"
+	// WebhookRetryableStatusCodes is a comma separated list of the
+	// response status codes that are retried. Other status codes outside
+	// 2xx fail the delivery for good. Defaults to 408, 429 and 5xx.
+	WebhookRetryableStatusCodes = "retryableStatusCodes"
+	// WebhookHeaderPrefix prefixes the settings adding a header to the
+	// requests, e.g. "header.Authorization".
+	WebhookHeaderPrefix = "header."
+	// WebhookSigningSecretName names the Secret holding the HMAC key the
+	// requests are signed with. Requests are not signed without one.
+	WebhookSigningSecretName = "signingSecretName"
+	// WebhookSigningSecretNamespace is the namespace of the signing
+	// Secret. Required for channels. Subscriptions can only use Secrets in
+	// their own namespace, which is the default, so that they can't have
+	// requests signed with the keys of other namespaces.
+	WebhookSigningSecretNamespace = "signingSecretNamespace"
+	// WebhookSigningSecretKey is the key of the HMAC key in the signing
+	// Secret. Defaults to "key".
+	WebhookSigningSecretKey = "signingSecretKey"
+	// WebhookFormat is how deliveries are encoded, one of the Format
+	// constants. Defaults to FormatEnvelope. The CloudEvents attributes of
+	// binary mode are sent as Ce- headers.
+	WebhookFormat = "format"
+)
+
+// Headers of the requests of the Webhook sink, besides DeliveryHeader.
+const (
+	// WebhookTimestampHeader holds the Unix time the request was signed.
+	WebhookTimestampHeader = "X-Message-Timestamp"
+	// WebhookSignatureHeader holds "sha256=" followed by the hex encoded
+	// HMAC-SHA256 of the timestamp header, a dot and the body.
+	WebhookSignatureHeader = "X-Message-Signature"
+)
+
+// WebhookSink broadcasts Messages by POSTing them to an HTTP endpoint.
+type WebhookSink struct {
+	name      string
+	url       string
+	format    string
+	headers   http.Header
+	retryable map[int]bool
+	client    *http.Client
+
+	signingSecret *secretRef
+	signingKey    string
+}
+
+func NewWebhookSink(name string, settings map[string]string, config Config) (*WebhookSink, error) {
+	s := &WebhookSink{
+		name:       name,
+		url:        settings[WebhookURL],
+		headers:    http.Header{},
+		retryable:  map[int]bool{http.StatusRequestTimeout: true, http.StatusTooManyRequests: true},
+		signingKey: "key",
+		format:     FormatEnvelope,
+	}
+
+	u, err := url.Parse(s.url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("setting %s must be an http or https URL, got %q", WebhookURL, s.url)
+	}
+
+	if format, ok := settings[WebhookFormat]; ok {
+		if err := validateFormat(format); err != nil {
+			return nil, fmt.Errorf("setting %s: %v", WebhookFormat, err)
+		}
+		s.format = format
+	}
+
+	timeout := 10 * time.Second
+	if value, ok := settings[WebhookTimeout]; ok {
+		if timeout, err = time.ParseDuration(value); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("setting %s must be a positive duration, got %q", WebhookTimeout, value)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if bundle, ok := settings[WebhookCABundle]; ok {
+		pool, err := certPool(WebhookCABundle, bundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	s.client = &http.Client{Transport: transport, Timeout: timeout}
+
+	if value, ok := settings[WebhookRetryableStatusCodes]; ok {
+		s.retryable = map[int]bool{}
+		for _, field := range strings.Split(value, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("setting %s must be a comma separated list of status codes, got %q", WebhookRetryableStatusCodes, value)
+			}
+			s.retryable[code] = true
+		}
+	} else {
+		for code := 500; code < 600; code++ {
+			s.retryable[code] = true
+		}
+	}
+
+	for key, value := range settings {
+		if header := strings.TrimPrefix(key, WebhookHeaderPrefix); header != key {
+			s.headers.Set(header, value)
+		}
+	}
+
+	if name := settings[WebhookSigningSecretName]; name != "" {
+		s.signingSecret, err = newSecretRef(config, name, settings[WebhookSigningSecretNamespace], WebhookSigningSecretNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := settings[WebhookSigningSecretKey]; ok {
+			s.signingKey = key
+		}
+	}
+	return s, nil
+}
+
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// DeliversEvents tells whether deliveries are encoded as CloudEvents, whose
+// consumers want every status change of a Message.
+func (s *WebhookSink) DeliversEvents() bool {
+	return s.format != FormatEnvelope
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, delivery *Delivery) error {
+	encoded, err := encode(s.format, delivery)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	extra := http.Header{}
+	for name, value := range encoded.attributes {
+		extra.Set("Ce-"+name, value)
+	}
+	return s.post(ctx, delivery.ID, encoded.contentType, encoded.body, extra)
+}
+
+// post POSTs body to the webhook, signed if a signing Secret is set, with
+// the configured headers and then extra.
+func (s *WebhookSink) post(ctx context.Context, deliveryID, contentType string, body []byte, extra http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	for header, values := range s.headers {
+		req.Header[header] = values
+	}
+	for header, values := range extra {
+		req.Header[header] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+
+	if s.signingSecret != nil {
+		key, err := s.signingSecret.value(ctx, s.signingKey)
+		if err != nil {
+			return err
+		}
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain a bit of the body so that the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("POST %s: %s", s.url, resp.Status)
+	if s.retryable[resp.StatusCode] {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookSinkDeliver(t *testing.T) {
+	key := []byte("s3cr3t")
+	var (
+		request *http.Request
+		body    []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	secrets := testSecrets(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "webhook-signing"},
+		Data:       map[string][]byte{"key": key},
+	})
+	s, err := NewWebhookSink("oncall", map[string]string{
+		WebhookURL:                  server.URL,
+		WebhookSigningSecretName:    "webhook-signing",
+		WebhookHeaderPrefix + "X-A": "b",
+	}, Config{Namespace: "team-a", Secrets: secrets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery := NewDelivery(testMessage(), s.Name(), "")
+	if err := s.Deliver(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := request.Header.Get(DeliveryHeader); got != delivery.ID {
+		t.Errorf("got delivery ID %q, want %q", got, delivery.ID)
+	}
+	if got := request.Header.Get("X-A"); got != "b" {
+		t.Errorf("got header X-A %q, want %q", got, "b")
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(request.Header.Get(WebhookTimestampHeader) + "."))
+	mac.Write(body)
+	if got, want := request.Header.Get(WebhookSignatureHeader), "sha256="+hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("got signature %q, want %q", got, want)
+	}
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Version != EnvelopeVersion || envelope.DeliveryID != delivery.ID || envelope.Message != "disk /var is 95% full" {
+		t.Errorf("got envelope %+v", envelope)
+	}
+}
+
+func TestWebhookSinkStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryable     string
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "bad request", status: http.StatusBadRequest, wantErr: true, wantPermanent: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantErr: true},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantErr: true},
+		{name: "retryable setting", status: http.StatusConflict, retryable: "409", wantErr: true},
+		{name: "not in retryable setting", status: http.StatusServiceUnavailable, retryable: "409", wantErr: true, wantPermanent: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+			settings := map[string]string{WebhookURL: server.URL}
+			if test.retryable != "" {
+				settings[WebhookRetryableStatusCodes] = test.retryable
+			}
+			s, err := NewWebhookSink("oncall", settings, Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Deliver(context.Background(), NewDelivery(testMessage(), s.Name(), ""))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want one: %v", err, test.wantErr)
+			}
+			if IsPermanent(err) != test.wantPermanent {
+				t.Errorf("got permanent %v for %v, want %v", IsPermanent(err), err, test.wantPermanent)
+			}
+		})
+	}
+}
+
+func TestWebhookSinkCloudEventsBinary(t *testing.T) {
+	var request *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+	}))
+	defer server.Close()
+
+	s, err := NewWebhookSink("oncall", map[string]string{
+		WebhookURL:    server.URL,
+		WebhookFormat: FormatCloudEventsBinary,
+	}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Deliver(context.Background(), NewDelivery(testMessage(), s.Name(), "")); err != nil {
+		t.Fatal(err)
+	}
+	for header, want := range map[string]string{
+		"Ce-Specversion": CloudEventsSpecVersion,
+		"Ce-Type":        EventBroadcasted,
+		"Ce-Source":      "/namespaces/team-a/messages/disk-full",
+		"Content-Type":   "application/json",
+	} {
+		if got := request.Header.Get(header); got != want {
+			t.Errorf("got header %s %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestWebhookSinkSigningSecretNamespace(t *testing.T) {
+	_, err := NewWebhookSink("oncall", map[string]string{
+		WebhookURL:                    "https://example.com/hook",
+		WebhookSigningSecretName:      "webhook-signing",
+		WebhookSigningSecretNamespace: "kube-system",
+	}, Config{Namespace: "team-a", Secrets: testSecrets()})
+	if err == nil {
+		t.Error("Subscription in team-a can sign with a Secret in kube-system")
+	}
+}